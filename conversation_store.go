@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// Turn represents a single message turn persisted for a user's conversation.
+type Turn struct {
+	Role      string           `json:"role"` // "user" or "assistant"
+	Content   string           `json:"content"`
+	Timestamp time.Time        `json:"timestamp"`
+	Tokens    int              `json:"tokens,omitempty"`
+	ToolCalls []ToolCallRecord `json:"toolCalls,omitempty"`
+}
+
+// ToolCallRecord captures a single tool invocation that occurred while
+// generating an assistant turn, so it can be replayed in the transcript.
+type ToolCallRecord struct {
+	Name      string    `json:"name"`
+	Args      string    `json:"args"`
+	Result    string    `json:"result"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ConversationStore persists full conversation transcripts per user and
+// replaces the old in-process conversationHistory map, so any agent
+// instance behind a load balancer sees the same history.
+type ConversationStore interface {
+	// AppendTurn records a new turn for userID. When CONVO_STORE_TTL is set,
+	// the backend evicts turns older than it; there is no size-based limit.
+	AppendTurn(userID string, turn Turn) error
+
+	// GetHistory returns up to limit turns for userID starting at offset,
+	// most recent first, so callers can paginate real transcripts.
+	GetHistory(userID string, limit, offset int) ([]Turn, error)
+
+	// Summary returns the lightweight aggregate metrics still used by
+	// /api/conversations/:userId and /api/metrics.
+	Summary(userID string) (ConversationSummary, error)
+
+	// AllSummaries returns one summary per known user, for /api/metrics.
+	AllSummaries() ([]ConversationSummary, error)
+
+	Close() error
+}
+
+// ConversationSummary is the small aggregate view of a user's conversation,
+// kept separate from the full transcript so list/metrics endpoints stay cheap.
+type ConversationSummary struct {
+	UserID       string
+	MessageCount int
+	LastMessage  string
+	Timestamp    time.Time
+}
+
+// conversationStore is the process-wide store selected at startup via
+// CONVO_STORE_BACKEND. It replaces the old package-level conversationHistory map.
+var conversationStore ConversationStore
+
+// userLocks serializes AppendTurn calls for the same userID within one
+// process, independent of whatever locking the backend itself does
+// internally. It is held only for the duration of a single AppendTurn; a
+// GetHistory followed by an AppendTurn is not covered as one unit, and
+// other instances behind a load balancer don't share it.
+type userLocks struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+func newUserLocks() *userLocks {
+	return &userLocks{locks: make(map[string]*sync.Mutex)}
+}
+
+func (u *userLocks) Lock(userID string) func() {
+	u.mu.Lock()
+	l, ok := u.locks[userID]
+	if !ok {
+		l = &sync.Mutex{}
+		u.locks[userID] = l
+	}
+	u.mu.Unlock()
+
+	l.Lock()
+	return l.Unlock
+}
+
+// newConversationStore selects and constructs the ConversationStore backend
+// from CONVO_STORE_BACKEND (sqlite|postgres|redis), defaulting to sqlite, and
+// runs its migrations before returning.
+func newConversationStore() (ConversationStore, error) {
+	backend := os.Getenv("CONVO_STORE_BACKEND")
+	if backend == "" {
+		backend = "sqlite"
+	}
+
+	ttl := 0 * time.Second
+	if raw := os.Getenv("CONVO_STORE_TTL"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CONVO_STORE_TTL: %w", err)
+		}
+		ttl = parsed
+	}
+
+	var store ConversationStore
+	var err error
+
+	switch backend {
+	case "sqlite":
+		path := os.Getenv("SQLITE_PATH")
+		if path == "" {
+			path = "standup-bot.db"
+		}
+		store, err = newSQLiteStore(path, ttl)
+	case "postgres":
+		dsn := os.Getenv("POSTGRES_DSN")
+		if dsn == "" {
+			return nil, fmt.Errorf("POSTGRES_DSN is required when CONVO_STORE_BACKEND=postgres")
+		}
+		store, err = newPostgresStore(dsn, ttl)
+	case "redis":
+		addr := os.Getenv("REDIS_ADDR")
+		if addr == "" {
+			addr = "localhost:6379"
+		}
+		store, err = newRedisStore(addr, os.Getenv("REDIS_PASSWORD"), ttl)
+	default:
+		return nil, fmt.Errorf("unknown CONVO_STORE_BACKEND %q", backend)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("initializing %s conversation store: %w", backend, err)
+	}
+
+	log.Printf("💾 Conversation store backend: %s", backend)
+	return store, nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// functionCallsStopSequence closes a function-call block; passed to
+// providers as a stop sequence so generation halts right after it instead
+// of wasting tokens on text the loop would discard anyway.
+const functionCallsStopSequence = "</function_calls>"
+
+type functionCallBlock struct {
+	XMLName xml.Name         `xml:"function_calls"`
+	Invokes []functionInvoke `xml:"invoke"`
+}
+
+type functionInvoke struct {
+	Name       string          `xml:"name,attr"`
+	Parameters []functionParam `xml:"parameter"`
+}
+
+type functionParam struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:",chardata"`
+}
+
+// parseFunctionCalls looks for a <function_calls>...</function_calls> block
+// in text and decodes it with a streaming XML decoder. ok is false if no
+// well-formed block is present, which callers treat as "no more tool calls".
+//
+// Providers are given functionCallsStopSequence as a stop sequence so
+// generation halts right after the block, but every OpenAI-compatible and
+// Anthropic API strips the matched stop string from the returned content —
+// the closing tag itself never comes back. So if the block's close is
+// missing, we treat the rest of text as the block and supply the closing
+// tag ourselves rather than requiring the literal substring.
+func parseFunctionCalls(text string) (block functionCallBlock, ok bool) {
+	start := strings.Index(text, "<function_calls>")
+	if start == -1 {
+		return block, false
+	}
+
+	blockText := text[start:]
+	if end := strings.Index(blockText, functionCallsStopSequence); end != -1 {
+		blockText = blockText[:end+len(functionCallsStopSequence)]
+	} else {
+		blockText += functionCallsStopSequence
+	}
+
+	decoder := xml.NewDecoder(strings.NewReader(blockText))
+	if err := decoder.Decode(&block); err != nil {
+		return block, false
+	}
+	return block, true
+}
+
+// executeFunctionCalls invokes every <invoke> in block against the tool
+// registry and renders a <function_results> block to feed back into the
+// conversation, alongside a ToolCallRecord per invocation for the transcript.
+func executeFunctionCalls(block functionCallBlock) (string, []ToolCallRecord) {
+	var results strings.Builder
+	results.WriteString("<function_results>\n")
+
+	records := make([]ToolCallRecord, 0, len(block.Invokes))
+
+	for _, invoke := range block.Invokes {
+		args := make(map[string]interface{}, len(invoke.Parameters))
+		argPairs := make([]string, 0, len(invoke.Parameters))
+		for _, param := range invoke.Parameters {
+			value := strings.TrimSpace(param.Value)
+			args[param.Name] = value
+			argPairs = append(argPairs, fmt.Sprintf("%s=%s", param.Name, value))
+		}
+
+		tool, known := toolRegistry[invoke.Name]
+
+		var output string
+		var err error
+		if !known {
+			err = fmt.Errorf("unknown tool %q", invoke.Name)
+		} else {
+			output, err = tool.Invoke(args)
+		}
+
+		result := output
+		if err != nil {
+			result = fmt.Sprintf("error: %v", err)
+		}
+
+		results.WriteString(fmt.Sprintf("<result name=%q>%s</result>\n", invoke.Name, result))
+
+		records = append(records, ToolCallRecord{
+			Name:      invoke.Name,
+			Args:      strings.Join(argPairs, ", "),
+			Result:    result,
+			Timestamp: time.Now(),
+		})
+	}
+
+	results.WriteString("</function_results>")
+	return results.String(), records
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// clientTimeouts gives each named outbound dependency a timeout suited to
+// what it talks to, instead of every call site picking its own.
+var clientTimeouts = map[string]time.Duration{
+	"telex":     10 * time.Second,
+	"telex-sse": 5 * time.Second,
+	"groq":      30 * time.Second,
+	"openai":    30 * time.Second,
+	"anthropic": 30 * time.Second,
+	"ollama":    60 * time.Second,
+	"localai":   60 * time.Second,
+	"web_fetch": 10 * time.Second,
+	"weather":   10 * time.Second,
+}
+
+const httpClientDefaultTimeout = 15 * time.Second
+
+var (
+	httpClientsMu sync.Mutex
+	httpClients   = make(map[string]*http.Client)
+)
+
+// newHTTPClient returns the *http.Client every outbound call (Telex, an LLM
+// provider, a tool) should use, so proxy handling, TLS policy, connection
+// pooling, and request instrumentation are defined once instead of each
+// call site constructing its own *http.Client ad hoc. One client is built
+// per name on first use and shared by every later call with that name.
+// name identifies the dependency for logging, metrics, and the per-name
+// timeout table above.
+func newHTTPClient(name string) *http.Client {
+	httpClientsMu.Lock()
+	defer httpClientsMu.Unlock()
+	if client, ok := httpClients[name]; ok {
+		return client
+	}
+
+	timeout, ok := clientTimeouts[name]
+	if !ok {
+		timeout = httpClientDefaultTimeout
+	}
+
+	transport := &http.Transport{
+		Proxy:               http.ProxyFromEnvironment, // honors HTTPS_PROXY/NO_PROXY
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+	}
+	if os.Getenv("INSECURE_SKIP_VERIFY") == "true" {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	if name == "web_fetch" {
+		// web_fetch dials attacker/model-supplied URLs; block SSRF into the
+		// host's internal network.
+		transport.DialContext = safeDialContext
+	}
+
+	client := &http.Client{
+		Timeout:   timeout,
+		Transport: &instrumentedRoundTripper{name: name, next: transport},
+	}
+	httpClients[name] = client
+	return client
+}
+
+const (
+	roundTripMaxAttempts = 3
+	roundTripBaseDelay   = 200 * time.Millisecond
+)
+
+// instrumentedRoundTripper wraps a transport with a per-request ID,
+// structured logging, and retry-with-jitter on transient (network/429/5xx)
+// failures, recording latency for every attempt in httpClientDuration so the
+// Prometheus /metrics endpoint can report it per dependency.
+type instrumentedRoundTripper struct {
+	name string
+	next http.RoundTripper
+}
+
+func (t *instrumentedRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestID := nextRequestID(t.name)
+	req.Header.Set("X-Request-ID", requestID)
+
+	var resp *http.Response
+	var err error
+
+	for attempt := 0; attempt < roundTripMaxAttempts; attempt++ {
+		start := time.Now()
+		resp, err = t.next.RoundTrip(req)
+		elapsed := time.Since(start)
+
+		retryable := err != nil || (resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500))
+		recordHTTPClientCall(t.name, elapsed, !retryable)
+
+		if !retryable {
+			log.Printf("🌐 [%s] %s %s -> %s (%s)", requestID, req.Method, req.URL.Path, statusOf(resp), elapsed)
+			return resp, err
+		}
+
+		log.Printf("⚠️  [%s] %s %s attempt %d/%d failed: %s", requestID, req.Method, req.URL.Path, attempt+1, roundTripMaxAttempts, statusOf(resp))
+
+		if attempt == roundTripMaxAttempts-1 {
+			break
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		// The failed attempt consumed the request body; rewind it so the
+		// retry sends the same payload.
+		if req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, bodyErr
+			}
+			req.Body = body
+		}
+
+		delay := roundTripBaseDelay*time.Duration(1<<uint(attempt)) + time.Duration(rand.Int63n(int64(roundTripBaseDelay)))
+		time.Sleep(delay)
+	}
+
+	return resp, err
+}
+
+func statusOf(resp *http.Response) string {
+	if resp == nil {
+		return "no response"
+	}
+	return resp.Status
+}
+
+var requestIDCounter int64
+
+// nextRequestID produces a unique ID per outbound call for the X-Request-ID
+// header and log correlation, without pulling in a UUID dependency.
+func nextRequestID(name string) string {
+	n := atomic.AddInt64(&requestIDCounter, 1)
+	return fmt.Sprintf("%s-%d-%d", name, time.Now().UnixNano(), n)
+}
+
+// httpClientDuration is a real Prometheus histogram of outbound call
+// latency, labeled by dependency name and outcome, so /metrics gives
+// ops proper buckets/percentiles instead of a hand-rolled average. Scraped
+// via the promhttp handler registered on /metrics in main.go.
+var httpClientDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "standup_bot_http_client_duration_seconds",
+	Help:    "Latency of outbound HTTP calls made by standup-bot, by dependency and outcome.",
+	Buckets: prometheus.DefBuckets,
+}, []string{"name", "outcome"})
+
+func recordHTTPClientCall(name string, elapsed time.Duration, ok bool) {
+	outcome := "success"
+	if !ok {
+		outcome = "error"
+	}
+	httpClientDuration.WithLabelValues(name, outcome).Observe(elapsed.Seconds())
+}
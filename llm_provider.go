@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"sync"
+)
+
+// LLMMessage is the provider-agnostic wire shape for a single chat turn,
+// distinct from the persisted Turn so storage and transport can evolve
+// independently.
+type LLMMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// LLMParams carries the knobs every provider understands. Model is optional
+// per request; an empty value falls back to the provider's own default.
+type LLMParams struct {
+	Model       string
+	Temperature float64
+	MaxTokens   int
+	Stop        []string
+}
+
+// TokenUsage is the unified accounting struct every provider fills in, so
+// /api/metrics can report consumption without caring which backend answered.
+type TokenUsage struct {
+	PromptTokens     int `json:"promptTokens"`
+	CompletionTokens int `json:"completionTokens"`
+	TotalTokens      int `json:"totalTokens"`
+}
+
+// LLMResponse is a completed (non-streamed) reply from a provider.
+type LLMResponse struct {
+	Content  string
+	Provider string
+	Usage    TokenUsage
+}
+
+// LLMProvider is implemented by each backend (Groq, OpenAI, Anthropic,
+// Ollama, LocalAI) so callers can swap or chain them without caring which
+// wire format the backend speaks.
+type LLMProvider interface {
+	Name() string
+	Complete(ctx context.Context, messages []LLMMessage, params LLMParams) (LLMResponse, error)
+	Stream(ctx context.Context, messages []LLMMessage, params LLMParams) (<-chan StreamChunk, error)
+}
+
+// llmChain is the process-wide fallback chain built at startup from
+// whichever provider credentials are present in the environment.
+var llmChain *providerChain
+
+// splitSystemMessage pulls a leading system message out of messages, for
+// providers (Anthropic) whose wire format takes the system prompt as a
+// separate field rather than a message with role "system".
+func splitSystemMessage(messages []LLMMessage) (system string, rest []LLMMessage) {
+	for _, msg := range messages {
+		if msg.Role == "system" {
+			system = msg.Content
+			continue
+		}
+		rest = append(rest, msg)
+	}
+	return system, rest
+}
+
+var (
+	providerUsageMu sync.Mutex
+	providerUsage   = make(map[string]TokenUsage)
+)
+
+// recordProviderUsage accumulates token usage per provider for /api/metrics.
+func recordProviderUsage(provider string, usage TokenUsage) {
+	providerUsageMu.Lock()
+	defer providerUsageMu.Unlock()
+
+	agg := providerUsage[provider]
+	agg.PromptTokens += usage.PromptTokens
+	agg.CompletionTokens += usage.CompletionTokens
+	agg.TotalTokens += usage.TotalTokens
+	providerUsage[provider] = agg
+}
+
+func snapshotProviderUsage() map[string]TokenUsage {
+	providerUsageMu.Lock()
+	defer providerUsageMu.Unlock()
+
+	out := make(map[string]TokenUsage, len(providerUsage))
+	for k, v := range providerUsage {
+		out[k] = v
+	}
+	return out
+}
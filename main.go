@@ -2,17 +2,20 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 // Message structures for Telex.im
@@ -50,17 +53,6 @@ type AgentResponse struct {
 	Confidence float64           `json:"confidence,omitempty"`
 }
 
-type ConversationContext struct {
-	UserID       string
-	LastMessage  string
-	MessageCount int
-	Topics       []string
-	Timestamp    time.Time
-}
-
-// Agent state management
-var conversationHistory = make(map[string]*ConversationContext)
-
 // Groq AI configuration (FREE API!)
 var (
 	groqAPIKey   string
@@ -77,9 +69,14 @@ func main() {
 	telexAPIKey = os.Getenv("TELEX_API_KEY")
 	telexBaseURL = os.Getenv("TELEX_BASE_URL")
 	agentID = os.Getenv("AGENT_ID")
+	webhookSecret = os.Getenv("WEBHOOK_SECRET")
+
+	if telexAPIKey == "" {
+		log.Fatal("Missing required TELEX_API_KEY in environment")
+	}
 
-	if groqAPIKey == "" || telexAPIKey == "" {
-		log.Fatal("Missing required API keys in environment")
+	if webhookSecret == "" {
+		log.Printf("⚠️  WEBHOOK_SECRET not set — webhook signature verification is disabled")
 	}
 
 	if telexBaseURL == "" {
@@ -90,6 +87,27 @@ func main() {
 		agentID = "ai-agent-001"
 	}
 
+	llmChain = newProviderChain()
+	if len(llmChain.providers) == 0 {
+		log.Fatal("No LLM providers configured (set GROQ_API_KEY, OPENAI_API_KEY, ANTHROPIC_API_KEY, OLLAMA_BASE_URL, or LOCALAI_BASE_URL)")
+	}
+
+	store, err := newConversationStore()
+	if err != nil {
+		log.Fatalf("Failed to initialize conversation store: %v", err)
+	}
+	conversationStore = store
+	defer conversationStore.Close()
+
+	if persist, ok := store.(StandupPersistence); ok {
+		standupPersistence = persist
+	} else {
+		log.Printf("⚠️  Conversation store backend doesn't support standup persistence — teams and schedules are in-memory only and won't survive a restart")
+	}
+	if err := loadStandupState(); err != nil {
+		log.Fatalf("Failed to load persisted standup state: %v", err)
+	}
+
 	// Initialize Gin router
 	r := gin.Default()
 
@@ -108,8 +126,19 @@ func main() {
 	// Conversation history endpoint
 	r.GET("/api/conversations/:userId", getConversationHistory)
 
-	// Metrics endpoint
+	// Streaming message endpoints (SSE + WebSocket)
+	r.GET("/api/message/stream", handleMessageStreamSSE)
+	r.GET("/api/message/ws", handleMessageStreamWS)
+
+	// Metrics endpoints: /api/metrics is app-level (conversations, token
+	// usage), /metrics is the Prometheus scrape target for outbound HTTP
+	// call latency (httpclient.go's httpClientDuration).
 	r.GET("/api/metrics", getMetrics)
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	// Team/standup schedule CRUD + the scheduler that drives them
+	registerStandupRoutes(r)
+	startStandupScheduler()
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -118,7 +147,7 @@ func main() {
 
 	log.Printf("🤖 AI Agent starting on port %s", port)
 	log.Printf("📡 Telex.im integration ready")
-	log.Printf("⚡ Using Groq AI (FREE - Llama 3.3 70B)")
+	log.Printf("⚡ LLM providers: %d configured", len(llmChain.providers))
 	r.Run(":" + port)
 }
 
@@ -144,8 +173,31 @@ func handleTelexWebhook(c *gin.Context) {
 		return
 	}
 
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		log.Printf("❌ Error reading webhook body: %v", err)
+		c.JSON(http.StatusBadRequest, TelexResponse{
+			Status:  "error",
+			Message: "Invalid payload",
+		})
+		return
+	}
+
+	if webhookSecret != "" {
+		signature := c.GetHeader("X-Telex-Signature")
+		timestamp := c.GetHeader("X-Telex-Timestamp")
+		if err := verifyWebhookSignature(body, signature, timestamp); err != nil {
+			log.Printf("⚠️  Webhook signature verification failed: %v", err)
+			c.JSON(http.StatusUnauthorized, TelexResponse{
+				Status:  "error",
+				Message: "Invalid signature",
+			})
+			return
+		}
+	}
+
 	var webhook TelexWebhook
-	if err := c.ShouldBindJSON(&webhook); err != nil {
+	if err := json.Unmarshal(body, &webhook); err != nil {
 		log.Printf("❌ Error parsing webhook: %v", err)
 		c.JSON(http.StatusBadRequest, TelexResponse{
 			Status:  "error",
@@ -178,23 +230,36 @@ func handleIncomingMessage(c *gin.Context, msg TelexMessage) {
 		return
 	}
 
+	// A standup reply isn't a chat turn for the AI to answer — just record
+	// it against the open standup run and skip response generation entirely.
+	if recordStandupResponse(msg.From, msg.Content) {
+		c.JSON(http.StatusOK, TelexResponse{Status: "success", Message: "Standup response recorded"})
+		return
+	}
+
 	// Update conversation context
 	updateConversationContext(msg.From, msg.Content)
 
-	// Generate AI response
-	agentReply, err := generateAIResponse(msg.From, msg.Content)
+	ctx, cancel := context.WithTimeout(context.Background(), streamResponseTimeout)
+	defer cancel()
+
+	chunks, toolCalls, err := generateAIResponseStream(ctx, msg.From, msg.Content)
 	if err != nil {
 		log.Printf("❌ Error generating AI response: %v", err)
-		agentReply = &AgentResponse{
-			Reply:      "I apologize, but I'm having trouble processing your message right now. Please try again.",
-			Confidence: 0.0,
+		if sendErr := sendTelexMessage(msg.From, "I apologize, but I'm having trouble processing your message right now. Please try again."); sendErr != nil {
+			log.Printf("❌ Error sending fallback message to Telex: %v", sendErr)
 		}
+		c.JSON(http.StatusOK, TelexResponse{Status: "success", Message: "Message processed"})
+		return
+	}
+
+	if err := sendTelexTypingEvent(msg.From); err != nil {
+		log.Printf("⚠️  Failed to send typing indicator: %v", err)
 	}
 
-	// Send response back to Telex.im
-	err = sendTelexMessage(msg.From, agentReply.Reply)
+	messageID, err := sendTelexMessageInitial(msg.From, "")
 	if err != nil {
-		log.Printf("❌ Error sending message to Telex: %v", err)
+		log.Printf("❌ Error sending initial message to Telex: %v", err)
 		c.JSON(http.StatusInternalServerError, TelexResponse{
 			Status:  "error",
 			Message: "Failed to send response",
@@ -202,7 +267,46 @@ func handleIncomingMessage(c *gin.Context, msg TelexMessage) {
 		return
 	}
 
-	log.Printf("✅ Sent reply to %s (confidence: %.2f)", msg.From, agentReply.Confidence)
+	var full strings.Builder
+	tokensSinceEdit := 0
+
+	for chunk := range chunks {
+		if chunk.Err != nil {
+			log.Printf("❌ Stream error for %s: %v", msg.From, chunk.Err)
+			break
+		}
+		if chunk.Done {
+			break
+		}
+
+		full.WriteString(chunk.Token)
+		tokensSinceEdit++
+
+		if tokensSinceEdit >= editEveryNTokens {
+			if err := editTelexMessage(messageID, msg.From, full.String()); err != nil {
+				log.Printf("⚠️  Failed to edit message %s: %v", messageID, err)
+			}
+			if err := sendTelexTypingEvent(msg.From); err != nil {
+				log.Printf("⚠️  Failed to refresh typing indicator: %v", err)
+			}
+			tokensSinceEdit = 0
+		}
+	}
+
+	if err := editTelexMessage(messageID, msg.From, full.String()); err != nil {
+		log.Printf("⚠️  Failed to finalize message %s: %v", messageID, err)
+	}
+
+	if err := conversationStore.AppendTurn(msg.From, Turn{
+		Role:      "assistant",
+		Content:   full.String(),
+		Timestamp: time.Now(),
+		ToolCalls: *toolCalls,
+	}); err != nil {
+		log.Printf("⚠️  Failed to persist assistant turn for %s: %v", msg.From, err)
+	}
+
+	log.Printf("✅ Sent reply to %s", msg.From)
 
 	c.JSON(http.StatusOK, TelexResponse{
 		Status:  "success",
@@ -228,8 +332,10 @@ func handleUserJoined(c *gin.Context, user TelexUser) {
 
 func handleDirectMessage(c *gin.Context) {
 	var req struct {
-		UserID  string `json:"userId" binding:"required"`
-		Message string `json:"message" binding:"required"`
+		UserID   string `json:"userId" binding:"required"`
+		Message  string `json:"message" binding:"required"`
+		Provider string `json:"provider,omitempty"`
+		Model    string `json:"model,omitempty"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -237,9 +343,14 @@ func handleDirectMessage(c *gin.Context) {
 		return
 	}
 
+	if recordStandupResponse(req.UserID, req.Message) {
+		c.JSON(http.StatusOK, TelexResponse{Status: "success", Message: "Standup response recorded"})
+		return
+	}
+
 	updateConversationContext(req.UserID, req.Message)
 
-	agentReply, err := generateAIResponse(req.UserID, req.Message)
+	agentReply, err := generateAIResponse(req.UserID, req.Message, req.Provider, req.Model)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -248,15 +359,38 @@ func handleDirectMessage(c *gin.Context) {
 	c.JSON(http.StatusOK, agentReply)
 }
 
-func generateAIResponse(userID, message string) (*AgentResponse, error) {
-	// Get conversation context
-	ctx := conversationHistory[userID]
+// maxContextTurns bounds how many prior turns are pulled into the prompt
+// sent to the LLM provider, so multi-turn conversations don't grow the
+// request unbounded.
+const maxContextTurns = 10
+
+// generateAIResponse answers userID's message using providerName/model if
+// given, otherwise the default fallback chain, persisting the reply and the
+// tokens it cost.
+func generateAIResponse(userID, message, providerName, model string) (*AgentResponse, error) {
+	// Pull recent transcript for this user from the conversation store.
+	history, err := conversationStore.GetHistory(userID, maxContextTurns, 0)
+	if err != nil {
+		return nil, fmt.Errorf("loading conversation history: %w", err)
+	}
 
-	// Build context-aware prompt
-	contextPrompt := buildContextPrompt(ctx, message)
+	messages := buildContextMessages(history, message)
+	params := LLMParams{Model: model, Temperature: 0.7, MaxTokens: 1024}
 
-	// Call Groq API (FREE!)
-	groqResp, err := callGroqAPI(contextPrompt)
+	var resp LLMResponse
+	var toolCalls []ToolCallRecord
+	if providerName != "" {
+		provider, ok := getProvider(providerName)
+		if !ok {
+			return nil, fmt.Errorf("unknown LLM provider %q", providerName)
+		}
+		resp, toolCalls, err = runToolLoop(context.Background(), messages, params, provider.Complete)
+		if err == nil {
+			recordProviderUsage(provider.Name(), resp.Usage)
+		}
+	} else {
+		resp, toolCalls, err = runToolLoop(context.Background(), messages, params, llmChain.Complete)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -265,88 +399,45 @@ func generateAIResponse(userID, message string) (*AgentResponse, error) {
 	intent := extractIntent(message)
 	entities := extractEntities(message)
 
+	if err := conversationStore.AppendTurn(userID, Turn{
+		Role:      "assistant",
+		Content:   resp.Content,
+		Timestamp: time.Now(),
+		Tokens:    resp.Usage.TotalTokens,
+		ToolCalls: toolCalls,
+	}); err != nil {
+		log.Printf("⚠️  Failed to persist assistant turn for %s: %v", userID, err)
+	}
+
 	return &AgentResponse{
-		Reply:      groqResp,
+		Reply:      resp.Content,
 		Intent:     intent,
 		Entities:   entities,
 		Confidence: 0.90,
 	}, nil
 }
 
-func buildContextPrompt(ctx *ConversationContext, message string) string {
-	prompt := "You are a helpful AI assistant integrated with Telex.im messaging platform. "
+// buildContextMessages renders the real prior turns (oldest first) plus the
+// new message into the message list sent to an LLMProvider.
+func buildContextMessages(history []Turn, message string) []LLMMessage {
+	systemPrompt := "You are a helpful AI assistant integrated with Telex.im messaging platform. Respond naturally and helpfully.\n\n" + toolsSystemPrompt()
 
-	if ctx != nil && ctx.MessageCount > 0 {
-		prompt += fmt.Sprintf("This is message #%d in the conversation. ", ctx.MessageCount)
-		if len(ctx.Topics) > 0 {
-			prompt += fmt.Sprintf("Previous topics discussed: %s. ", strings.Join(ctx.Topics, ", "))
-		}
+	messages := []LLMMessage{
+		{Role: "system", Content: systemPrompt},
 	}
 
-	prompt += "Respond naturally and helpfully to the following message:\n\n" + message
-
-	return prompt
-}
-
-func callGroqAPI(prompt string) (string, error) {
-	reqBody := map[string]interface{}{
-		"model": "llama-3.3-70b-versatile", // FREE model - 70B parameters!
-		"messages": []map[string]string{
-			{
-				"role":    "user",
-				"content": prompt,
-			},
-		},
-		"temperature": 0.7,
-		"max_tokens":  1024,
-	}
-
-	jsonData, err := json.Marshal(reqBody)
-	if err != nil {
-		return "", err
+	// Callers persist the user's turn before generating, so the newest
+	// history entry is usually message itself; skip it so it isn't sent twice.
+	if len(history) > 0 && history[0].Role == "user" && history[0].Content == message {
+		history = history[1:]
 	}
 
-	req, err := http.NewRequest("POST", "https://api.groq.com/openai/v1/chat/completions", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return "", err
+	for i := len(history) - 1; i >= 0; i-- {
+		messages = append(messages, LLMMessage{Role: history[i].Role, Content: history[i].Content})
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+groqAPIKey)
-
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("groq API error: %s", string(body))
-	}
-
-	var groqResp struct {
-		Choices []struct {
-			Message struct {
-				Content string `json:"content"`
-			} `json:"message"`
-		} `json:"choices"`
-	}
-
-	if err := json.Unmarshal(body, &groqResp); err != nil {
-		return "", err
-	}
-
-	if len(groqResp.Choices) == 0 {
-		return "", fmt.Errorf("empty response from Groq")
-	}
-
-	return groqResp.Choices[0].Message.Content, nil
+	messages = append(messages, LLMMessage{Role: "user", Content: message})
+	return messages
 }
 
 func sendTelexMessage(toUserID, content string) error {
@@ -371,7 +462,7 @@ func sendTelexMessage(toUserID, content string) error {
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+telexAPIKey)
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := newHTTPClient("telex")
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
@@ -387,26 +478,12 @@ func sendTelexMessage(toUserID, content string) error {
 }
 
 func updateConversationContext(userID, message string) {
-	ctx, exists := conversationHistory[userID]
-	if !exists {
-		ctx = &ConversationContext{
-			UserID:       userID,
-			MessageCount: 0,
-			Topics:       []string{},
-		}
-		conversationHistory[userID] = ctx
-	}
-
-	ctx.LastMessage = message
-	ctx.MessageCount++
-	ctx.Timestamp = time.Now()
-
-	// Extract and add topics
-	topics := extractTopics(message)
-	for _, topic := range topics {
-		if !contains(ctx.Topics, topic) {
-			ctx.Topics = append(ctx.Topics, topic)
-		}
+	if err := conversationStore.AppendTurn(userID, Turn{
+		Role:      "user",
+		Content:   message,
+		Timestamp: time.Now(),
+	}); err != nil {
+		log.Printf("⚠️  Failed to persist user turn for %s: %v", userID, err)
 	}
 }
 
@@ -447,37 +524,6 @@ func extractEntities(message string) map[string]string {
 	return entities
 }
 
-func extractTopics(message string) []string {
-	topics := []string{}
-	lower := strings.ToLower(message)
-
-	keywords := map[string]string{
-		"code":    "programming",
-		"python":  "programming",
-		"go":      "programming",
-		"weather": "weather",
-		"help":    "support",
-		"how":     "tutorial",
-	}
-
-	for keyword, topic := range keywords {
-		if strings.Contains(lower, keyword) {
-			topics = append(topics, topic)
-		}
-	}
-
-	return topics
-}
-
-func contains(slice []string, item string) bool {
-	for _, s := range slice {
-		if s == item {
-			return true
-		}
-	}
-	return false
-}
-
 func getAgentInfo(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"agentId":      agentID,
@@ -494,35 +540,52 @@ func getAgentInfo(c *gin.Context) {
 func getConversationHistory(c *gin.Context) {
 	userID := c.Param("userId")
 
-	ctx, exists := conversationHistory[userID]
-	if !exists {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit <= 0 {
+		limit = 20
+	}
+
+	summary, err := conversationStore.Summary(userID)
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "No conversation found"})
 		return
 	}
 
+	turns, err := conversationStore.GetHistory(userID, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"userId":       ctx.UserID,
-		"messageCount": ctx.MessageCount,
-		"topics":       ctx.Topics,
-		"lastMessage":  ctx.LastMessage,
-		"timestamp":    ctx.Timestamp,
+		"userId":       summary.UserID,
+		"messageCount": summary.MessageCount,
+		"lastMessage":  summary.LastMessage,
+		"timestamp":    summary.Timestamp,
+		"turns":        turns,
+		"limit":        limit,
+		"offset":       offset,
 	})
 }
 
 func getMetrics(c *gin.Context) {
-	totalConversations := len(conversationHistory)
-	totalMessages := 0
+	summaries, err := conversationStore.AllSummaries()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 
-	for _, ctx := range conversationHistory {
-		totalMessages += ctx.MessageCount
+	totalMessages := 0
+	for _, summary := range summaries {
+		totalMessages += summary.MessageCount
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"totalConversations": totalConversations,
+		"totalConversations": len(summaries),
 		"totalMessages":      totalMessages,
-		"activeUsers":        totalConversations,
-		"aiProvider":         "Groq (FREE)",
-		"model":              "Llama 3.3 70B",
+		"activeUsers":        len(summaries),
+		"tokensByProvider":   snapshotProviderUsage(),
 		"timestamp":          time.Now(),
 	})
 }
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const anthropicAPIVersion = "2023-06-01"
+
+// anthropicProvider speaks Anthropic's native /v1/messages API, which
+// differs from the OpenAI-style providers: the system prompt is a top-level
+// field rather than a message, and stop sequences are "stop_sequences".
+type anthropicProvider struct {
+	apiKey string
+}
+
+func newAnthropicProvider(apiKey string) *anthropicProvider {
+	return &anthropicProvider{apiKey: apiKey}
+}
+
+func (p *anthropicProvider) Name() string { return "anthropic" }
+
+func (p *anthropicProvider) buildRequest(messages []LLMMessage, params LLMParams, stream bool) map[string]interface{} {
+	system, rest := splitSystemMessage(messages)
+
+	model := params.Model
+	if model == "" {
+		model = "claude-3-5-sonnet-20241022"
+	}
+	maxTokens := params.MaxTokens
+	if maxTokens == 0 {
+		maxTokens = 1024
+	}
+
+	reqBody := map[string]interface{}{
+		"model":       model,
+		"messages":    rest,
+		"max_tokens":  maxTokens,
+		"temperature": params.Temperature,
+		"stream":      stream,
+	}
+	if system != "" {
+		reqBody["system"] = system
+	}
+	if len(params.Stop) > 0 {
+		reqBody["stop_sequences"] = params.Stop
+	}
+	return reqBody
+}
+
+func (p *anthropicProvider) Complete(ctx context.Context, messages []LLMMessage, params LLMParams) (LLMResponse, error) {
+	return p.doComplete(ctx, p.buildRequest(messages, params, false))
+}
+
+func (p *anthropicProvider) doComplete(ctx context.Context, reqBody map[string]interface{}) (LLMResponse, error) {
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return LLMResponse{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.anthropic.com/v1/messages", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return LLMResponse{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", p.apiKey)
+	req.Header.Set("anthropic-version", anthropicAPIVersion)
+
+	client := newHTTPClient("anthropic")
+	resp, err := client.Do(req)
+	if err != nil {
+		return LLMResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return LLMResponse{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return LLMResponse{}, fmt.Errorf("anthropic API error (%d): %s", resp.StatusCode, string(body))
+	}
+
+	var parsed struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+		Usage struct {
+			InputTokens  int `json:"input_tokens"`
+			OutputTokens int `json:"output_tokens"`
+		} `json:"usage"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return LLMResponse{}, err
+	}
+
+	var text strings.Builder
+	for _, block := range parsed.Content {
+		if block.Type == "text" {
+			text.WriteString(block.Text)
+		}
+	}
+
+	return LLMResponse{
+		Content: text.String(),
+		Usage: TokenUsage{
+			PromptTokens:     parsed.Usage.InputTokens,
+			CompletionTokens: parsed.Usage.OutputTokens,
+			TotalTokens:      parsed.Usage.InputTokens + parsed.Usage.OutputTokens,
+		},
+	}, nil
+}
+
+func (p *anthropicProvider) Stream(ctx context.Context, messages []LLMMessage, params LLMParams) (<-chan StreamChunk, error) {
+	reqBody := p.buildRequest(messages, params, true)
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.anthropic.com/v1/messages", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", p.apiKey)
+	req.Header.Set("anthropic-version", anthropicAPIVersion)
+	req.Header.Set("Accept", "text/event-stream")
+
+	client := newHTTPClient("anthropic")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("anthropic API error (%d): %s", resp.StatusCode, string(body))
+	}
+
+	out := make(chan StreamChunk)
+
+	go func() {
+		defer close(out)
+		defer resp.Body.Close()
+
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+		for scanner.Scan() {
+			line := scanner.Text()
+			if !strings.HasPrefix(line, "data: ") {
+				continue
+			}
+
+			data := strings.TrimPrefix(line, "data: ")
+
+			var event struct {
+				Type  string `json:"type"`
+				Delta struct {
+					Type string `json:"type"`
+					Text string `json:"text"`
+				} `json:"delta"`
+			}
+			if err := json.Unmarshal([]byte(data), &event); err != nil {
+				continue
+			}
+
+			switch event.Type {
+			case "content_block_delta":
+				if event.Delta.Text == "" {
+					continue
+				}
+				select {
+				case out <- StreamChunk{Token: event.Delta.Text}:
+				case <-ctx.Done():
+					out <- StreamChunk{Err: ctx.Err()}
+					return
+				}
+			case "message_stop":
+				out <- StreamChunk{Done: true}
+				return
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			out <- StreamChunk{Err: err}
+		}
+	}()
+
+	return out, nil
+}
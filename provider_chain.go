@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+)
+
+// providerChain tries providers in order, falling through to the next one
+// if the current provider errors or rate-limits, so an outage at one vendor
+// doesn't take the whole agent down.
+type providerChain struct {
+	providers []LLMProvider
+}
+
+func (c *providerChain) Complete(ctx context.Context, messages []LLMMessage, params LLMParams) (LLMResponse, error) {
+	var lastErr error
+
+	for _, p := range c.providers {
+		resp, err := p.Complete(ctx, messages, params)
+		if err == nil {
+			resp.Provider = p.Name()
+			recordProviderUsage(p.Name(), resp.Usage)
+			return resp, nil
+		}
+		log.Printf("⚠️  Provider %s failed, trying next: %v", p.Name(), err)
+		lastErr = err
+	}
+
+	return LLMResponse{}, fmt.Errorf("all LLM providers failed: %w", lastErr)
+}
+
+func (c *providerChain) Stream(ctx context.Context, messages []LLMMessage, params LLMParams) (<-chan StreamChunk, error) {
+	var lastErr error
+
+	for _, p := range c.providers {
+		chunks, err := p.Stream(ctx, messages, params)
+		if err == nil {
+			return chunks, nil
+		}
+		log.Printf("⚠️  Provider %s failed to start stream, trying next: %v", p.Name(), err)
+		lastErr = err
+	}
+
+	return nil, fmt.Errorf("all LLM providers failed to start stream: %w", lastErr)
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// groqProvider is the free, ultra-fast default backend.
+type groqProvider struct {
+	apiKey string
+}
+
+func newGroqProvider(apiKey string) *groqProvider {
+	return &groqProvider{apiKey: apiKey}
+}
+
+func (p *groqProvider) Name() string { return "groq" }
+
+func (p *groqProvider) Complete(ctx context.Context, messages []LLMMessage, params LLMParams) (LLMResponse, error) {
+	model := params.Model
+	if model == "" {
+		model = "llama-3.3-70b-versatile"
+	}
+
+	reqBody := map[string]interface{}{
+		"model":       model,
+		"messages":    messages,
+		"temperature": params.Temperature,
+		"max_tokens":  params.MaxTokens,
+	}
+	if len(params.Stop) > 0 {
+		reqBody["stop"] = params.Stop
+	}
+
+	return completeOpenAICompatible(ctx, "groq", "https://api.groq.com/openai/v1/chat/completions", "Bearer "+p.apiKey, reqBody)
+}
+
+func (p *groqProvider) Stream(ctx context.Context, messages []LLMMessage, params LLMParams) (<-chan StreamChunk, error) {
+	model := params.Model
+	if model == "" {
+		model = "llama-3.3-70b-versatile"
+	}
+
+	reqBody := map[string]interface{}{
+		"model":       model,
+		"messages":    messages,
+		"temperature": params.Temperature,
+		"max_tokens":  params.MaxTokens,
+	}
+	if len(params.Stop) > 0 {
+		reqBody["stop"] = params.Stop
+	}
+
+	return streamOpenAICompatible(ctx, "groq", "https://api.groq.com/openai/v1/chat/completions", "Bearer "+p.apiKey, reqBody)
+}
+
+// completeOpenAICompatible sends a non-streamed chat completion to any
+// OpenAI-compatible endpoint (Groq, OpenAI, LocalAI) and normalizes the
+// response into an LLMResponse. Retries on network errors and 429/5xx
+// responses are handled once, underneath this call, by newHTTPClient's
+// instrumentedRoundTripper — this function doesn't retry on its own.
+// clientName selects the right entry in the shared httpclient timeout table.
+func completeOpenAICompatible(ctx context.Context, clientName, url, authHeader string, reqBody map[string]interface{}) (LLMResponse, error) {
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return LLMResponse{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return LLMResponse{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", authHeader)
+
+	client := newHTTPClient(clientName)
+	resp, err := client.Do(req)
+	if err != nil {
+		return LLMResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return LLMResponse{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return LLMResponse{}, fmt.Errorf("LLM API error (%d): %s", resp.StatusCode, string(body))
+	}
+
+	var parsed struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+		Usage struct {
+			PromptTokens     int `json:"prompt_tokens"`
+			CompletionTokens int `json:"completion_tokens"`
+			TotalTokens      int `json:"total_tokens"`
+		} `json:"usage"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return LLMResponse{}, err
+	}
+	if len(parsed.Choices) == 0 {
+		return LLMResponse{}, fmt.Errorf("empty response from LLM provider")
+	}
+
+	return LLMResponse{
+		Content: parsed.Choices[0].Message.Content,
+		Usage: TokenUsage{
+			PromptTokens:     parsed.Usage.PromptTokens,
+			CompletionTokens: parsed.Usage.CompletionTokens,
+			TotalTokens:      parsed.Usage.TotalTokens,
+		},
+	}, nil
+}
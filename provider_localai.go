@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"strings"
+)
+
+// localAIProvider talks to a self-hosted LocalAI instance, which mirrors
+// the OpenAI chat completions API.
+type localAIProvider struct {
+	baseURL string
+}
+
+func newLocalAIProvider(baseURL string) *localAIProvider {
+	return &localAIProvider{baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+func (p *localAIProvider) Name() string { return "localai" }
+
+func (p *localAIProvider) Complete(ctx context.Context, messages []LLMMessage, params LLMParams) (LLMResponse, error) {
+	model := params.Model
+	if model == "" {
+		model = "gpt-3.5-turbo"
+	}
+
+	reqBody := map[string]interface{}{
+		"model":       model,
+		"messages":    messages,
+		"temperature": params.Temperature,
+		"max_tokens":  params.MaxTokens,
+	}
+	if len(params.Stop) > 0 {
+		reqBody["stop"] = params.Stop
+	}
+
+	return completeOpenAICompatible(ctx, "localai", p.baseURL+"/v1/chat/completions", "Bearer local", reqBody)
+}
+
+func (p *localAIProvider) Stream(ctx context.Context, messages []LLMMessage, params LLMParams) (<-chan StreamChunk, error) {
+	model := params.Model
+	if model == "" {
+		model = "gpt-3.5-turbo"
+	}
+
+	reqBody := map[string]interface{}{
+		"model":       model,
+		"messages":    messages,
+		"temperature": params.Temperature,
+		"max_tokens":  params.MaxTokens,
+	}
+	if len(params.Stop) > 0 {
+		reqBody["stop"] = params.Stop
+	}
+
+	return streamOpenAICompatible(ctx, "localai", p.baseURL+"/v1/chat/completions", "Bearer local", reqBody)
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ollamaProvider talks to a local Ollama daemon's /api/chat endpoint, for
+// fully offline operation.
+type ollamaProvider struct {
+	baseURL string
+}
+
+func newOllamaProvider(baseURL string) *ollamaProvider {
+	return &ollamaProvider{baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+func (p *ollamaProvider) Name() string { return "ollama" }
+
+func (p *ollamaProvider) Complete(ctx context.Context, messages []LLMMessage, params LLMParams) (LLMResponse, error) {
+	model := params.Model
+	if model == "" {
+		model = "llama3.2"
+	}
+
+	options := map[string]interface{}{"temperature": params.Temperature}
+	if len(params.Stop) > 0 {
+		options["stop"] = params.Stop
+	}
+
+	reqBody := map[string]interface{}{
+		"model":    model,
+		"messages": messages,
+		"stream":   false,
+		"options":  options,
+	}
+
+	return p.doComplete(ctx, reqBody)
+}
+
+func (p *ollamaProvider) doComplete(ctx context.Context, reqBody map[string]interface{}) (LLMResponse, error) {
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return LLMResponse{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.baseURL+"/api/chat", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return LLMResponse{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := newHTTPClient("ollama")
+	resp, err := client.Do(req)
+	if err != nil {
+		return LLMResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return LLMResponse{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return LLMResponse{}, fmt.Errorf("ollama error (%d): %s", resp.StatusCode, string(body))
+	}
+
+	var parsed struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+		PromptEvalCount int `json:"prompt_eval_count"`
+		EvalCount       int `json:"eval_count"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return LLMResponse{}, err
+	}
+
+	return LLMResponse{
+		Content: parsed.Message.Content,
+		Usage: TokenUsage{
+			PromptTokens:     parsed.PromptEvalCount,
+			CompletionTokens: parsed.EvalCount,
+			TotalTokens:      parsed.PromptEvalCount + parsed.EvalCount,
+		},
+	}, nil
+}
+
+func (p *ollamaProvider) Stream(ctx context.Context, messages []LLMMessage, params LLMParams) (<-chan StreamChunk, error) {
+	model := params.Model
+	if model == "" {
+		model = "llama3.2"
+	}
+
+	options := map[string]interface{}{"temperature": params.Temperature}
+	if len(params.Stop) > 0 {
+		options["stop"] = params.Stop
+	}
+
+	reqBody := map[string]interface{}{
+		"model":    model,
+		"messages": messages,
+		"stream":   true,
+		"options":  options,
+	}
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.baseURL+"/api/chat", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := newHTTPClient("ollama")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("ollama error (%d): %s", resp.StatusCode, string(body))
+	}
+
+	out := make(chan StreamChunk)
+
+	go func() {
+		defer close(out)
+		defer resp.Body.Close()
+
+		// Ollama streams newline-delimited JSON objects, not SSE frames.
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			if len(line) == 0 {
+				continue
+			}
+
+			var frame struct {
+				Message struct {
+					Content string `json:"content"`
+				} `json:"message"`
+				Done bool `json:"done"`
+			}
+			if err := json.Unmarshal(line, &frame); err != nil {
+				continue
+			}
+
+			if frame.Message.Content != "" {
+				select {
+				case out <- StreamChunk{Token: frame.Message.Content}:
+				case <-ctx.Done():
+					out <- StreamChunk{Err: ctx.Err()}
+					return
+				}
+			}
+			if frame.Done {
+				out <- StreamChunk{Done: true}
+				return
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			out <- StreamChunk{Err: err}
+		}
+	}()
+
+	return out, nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import "context"
+
+// openAIProvider talks to the OpenAI chat completions API, which shares the
+// same wire format as Groq and LocalAI.
+type openAIProvider struct {
+	apiKey string
+}
+
+func newOpenAIProvider(apiKey string) *openAIProvider {
+	return &openAIProvider{apiKey: apiKey}
+}
+
+func (p *openAIProvider) Name() string { return "openai" }
+
+func (p *openAIProvider) Complete(ctx context.Context, messages []LLMMessage, params LLMParams) (LLMResponse, error) {
+	model := params.Model
+	if model == "" {
+		model = "gpt-4o-mini"
+	}
+
+	reqBody := map[string]interface{}{
+		"model":       model,
+		"messages":    messages,
+		"temperature": params.Temperature,
+		"max_tokens":  params.MaxTokens,
+	}
+	if len(params.Stop) > 0 {
+		reqBody["stop"] = params.Stop
+	}
+
+	return completeOpenAICompatible(ctx, "openai", "https://api.openai.com/v1/chat/completions", "Bearer "+p.apiKey, reqBody)
+}
+
+func (p *openAIProvider) Stream(ctx context.Context, messages []LLMMessage, params LLMParams) (<-chan StreamChunk, error) {
+	model := params.Model
+	if model == "" {
+		model = "gpt-4o-mini"
+	}
+
+	reqBody := map[string]interface{}{
+		"model":       model,
+		"messages":    messages,
+		"temperature": params.Temperature,
+		"max_tokens":  params.MaxTokens,
+	}
+	if len(params.Stop) > 0 {
+		reqBody["stop"] = params.Stop
+	}
+
+	return streamOpenAICompatible(ctx, "openai", "https://api.openai.com/v1/chat/completions", "Bearer "+p.apiKey, reqBody)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"os"
+	"strings"
+)
+
+// registeredProviders holds every provider whose credentials/base URL were
+// present at startup, keyed by name, so a per-request "provider" field can
+// bypass the fallback chain and target one directly.
+var registeredProviders = map[string]LLMProvider{}
+
+// getProvider looks up a provider by name for per-request selection.
+func getProvider(name string) (LLMProvider, bool) {
+	p, ok := registeredProviders[name]
+	return p, ok
+}
+
+// newProviderChain builds the fallback chain from whichever provider
+// credentials are present in the environment. LLM_PROVIDER_ORDER (a
+// comma-separated list) controls both which providers are active and the
+// fallback order; it defaults to groq,openai,anthropic,ollama,localai.
+func newProviderChain() *providerChain {
+	if groqAPIKey != "" {
+		registeredProviders["groq"] = newGroqProvider(groqAPIKey)
+	}
+	if key := os.Getenv("OPENAI_API_KEY"); key != "" {
+		registeredProviders["openai"] = newOpenAIProvider(key)
+	}
+	if key := os.Getenv("ANTHROPIC_API_KEY"); key != "" {
+		registeredProviders["anthropic"] = newAnthropicProvider(key)
+	}
+	if url := os.Getenv("OLLAMA_BASE_URL"); url != "" {
+		registeredProviders["ollama"] = newOllamaProvider(url)
+	}
+	if url := os.Getenv("LOCALAI_BASE_URL"); url != "" {
+		registeredProviders["localai"] = newLocalAIProvider(url)
+	}
+
+	order := os.Getenv("LLM_PROVIDER_ORDER")
+	var names []string
+	if order != "" {
+		names = strings.Split(order, ",")
+	} else {
+		names = []string{"groq", "openai", "anthropic", "ollama", "localai"}
+	}
+
+	var providers []LLMProvider
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if p, ok := registeredProviders[name]; ok {
+			providers = append(providers, p)
+		}
+	}
+
+	return &providerChain{providers: providers}
+}
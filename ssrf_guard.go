@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// safeDialContext wraps the dial step of an http.Transport so it refuses to
+// connect to loopback, private, link-local, or otherwise non-public IP
+// ranges. It's used by the web_fetch tool's client, since that tool dials
+// whatever URL the model (steered by an ordinary Telex user message) asks
+// for — without this, a user could point it at the cloud metadata endpoint
+// or an internal admin panel (SSRF). Resolution happens here and the
+// validated IP is dialed directly, rather than handing the hostname to the
+// dialer, so a DNS answer that changes between check and connect can't
+// smuggle a disallowed address through.
+func safeDialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	ips, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+
+	var lastErr error
+	for _, ip := range ips {
+		if isDisallowedFetchTarget(ip.IP) {
+			lastErr = fmt.Errorf("refusing to connect to disallowed address %s", ip.IP)
+			continue
+		}
+		conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip.IP.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no addresses resolved for %s", host)
+	}
+	return nil, lastErr
+}
+
+// isDisallowedFetchTarget reports whether ip is in a private, loopback,
+// link-local, unspecified, or multicast range that a server-side fetch tool
+// should never be allowed to reach.
+func isDisallowedFetchTarget(ip net.IP) bool {
+	return ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsUnspecified() ||
+		ip.IsMulticast()
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// generateStandupDigest turns a team's raw standup replies into a
+// yesterday/today/blockers summary per person plus an aggregated blockers
+// list, via the same LLM chain the chat path uses. This is deliberately
+// separate from the standup_summarizer tool, which only reformats text
+// mid-conversation rather than producing the channel-facing digest.
+func generateStandupDigest(team *Team, responses map[string]string) (string, error) {
+	var raw strings.Builder
+	for _, member := range team.Members {
+		reply, ok := responses[member]
+		if !ok {
+			continue
+		}
+		raw.WriteString(fmt.Sprintf("%s: %s\n", member, reply))
+	}
+
+	if raw.Len() == 0 {
+		return fmt.Sprintf("📋 Standup digest for %s: no one responded in time.", team.Name), nil
+	}
+
+	messages := []LLMMessage{
+		{
+			Role: "system",
+			Content: "You are a standup bot. For each teammate below, summarize their yesterday, " +
+				"today, and blockers in a few bullet points. Then add an \"Aggregated blockers\" " +
+				"section listing every blocker across the team. Be concise.",
+		},
+		{Role: "user", Content: raw.String()},
+	}
+
+	resp, err := llmChain.Complete(context.Background(), messages, LLMParams{Temperature: 0.3, MaxTokens: 800})
+	if err != nil {
+		return "", fmt.Errorf("generating standup digest: %w", err)
+	}
+
+	return fmt.Sprintf("📋 Standup digest for %s:\n\n%s", team.Name, resp.Content), nil
+}
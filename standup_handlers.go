@@ -0,0 +1,156 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// registerStandupRoutes wires the Team and StandupSchedule CRUD endpoints.
+func registerStandupRoutes(r *gin.Engine) {
+	r.POST("/api/teams", createTeamHandler)
+	r.GET("/api/teams", listTeamsHandler)
+	r.GET("/api/teams/:teamId", getTeamHandler)
+	r.DELETE("/api/teams/:teamId", deleteTeamHandler)
+
+	r.POST("/api/standups", createStandupScheduleHandler)
+	r.GET("/api/standups", listStandupSchedulesHandler)
+	r.GET("/api/standups/:scheduleId", getStandupScheduleHandler)
+	r.DELETE("/api/standups/:scheduleId", deleteStandupScheduleHandler)
+}
+
+func createTeamHandler(c *gin.Context) {
+	var req struct {
+		Name      string   `json:"name" binding:"required"`
+		ChannelID string   `json:"channelId" binding:"required"`
+		Members   []string `json:"members" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	team := &Team{
+		ID:        newStandupID("team"),
+		Name:      req.Name,
+		ChannelID: req.ChannelID,
+		Members:   req.Members,
+		CreatedAt: time.Now(),
+	}
+	standupStore.createTeam(team)
+
+	c.JSON(http.StatusCreated, team)
+}
+
+func listTeamsHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, standupStore.listTeams())
+}
+
+func getTeamHandler(c *gin.Context) {
+	team, ok := standupStore.getTeam(c.Param("teamId"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "team not found"})
+		return
+	}
+	c.JSON(http.StatusOK, team)
+}
+
+func deleteTeamHandler(c *gin.Context) {
+	teamID := c.Param("teamId")
+
+	// Cascade to the team's schedules first, the same way
+	// deleteStandupScheduleHandler would, so none are left orphaned: still
+	// registered with standupCron, but referencing a team that no longer
+	// exists.
+	for _, schedule := range standupStore.listSchedulesForTeam(teamID) {
+		deleteStandupSchedule(schedule.ID)
+	}
+
+	standupStore.deleteTeam(teamID)
+	c.JSON(http.StatusOK, gin.H{"status": "deleted"})
+}
+
+func createStandupScheduleHandler(c *gin.Context) {
+	var req struct {
+		TeamID         string   `json:"teamId" binding:"required"`
+		Cron           string   `json:"cron" binding:"required"`
+		Timezone       string   `json:"timezone"`
+		Questions      []string `json:"questions" binding:"required"`
+		ResponseWindow string   `json:"responseWindow"` // e.g. "2h", defaults below
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, ok := standupStore.getTeam(req.TeamID); !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "team not found"})
+		return
+	}
+
+	window := 2 * time.Hour
+	if req.ResponseWindow != "" {
+		parsed, err := time.ParseDuration(req.ResponseWindow)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid responseWindow: " + err.Error()})
+			return
+		}
+		window = parsed
+	}
+
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+
+	schedule := &StandupSchedule{
+		ID:             newStandupID("standup"),
+		TeamID:         req.TeamID,
+		Cron:           req.Cron,
+		Timezone:       timezone,
+		Questions:      req.Questions,
+		ResponseWindow: window,
+		CreatedAt:      time.Now(),
+	}
+
+	entryID, err := scheduleStandup(schedule)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cron expression: " + err.Error()})
+		return
+	}
+
+	standupStore.createSchedule(schedule)
+	standupStore.setCronEntry(schedule.ID, entryID)
+	c.JSON(http.StatusCreated, schedule)
+}
+
+func listStandupSchedulesHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, standupStore.listSchedules())
+}
+
+func getStandupScheduleHandler(c *gin.Context) {
+	schedule, ok := standupStore.getSchedule(c.Param("scheduleId"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "standup schedule not found"})
+		return
+	}
+	c.JSON(http.StatusOK, schedule)
+}
+
+func deleteStandupScheduleHandler(c *gin.Context) {
+	deleteStandupSchedule(c.Param("scheduleId"))
+	c.JSON(http.StatusOK, gin.H{"status": "deleted"})
+}
+
+// deleteStandupSchedule removes a schedule's cron entry (if any) and the
+// schedule itself. Shared by deleteStandupScheduleHandler and
+// deleteTeamHandler's cascade so a deleted team can't leave an orphaned
+// schedule still firing on standupCron.
+func deleteStandupSchedule(scheduleID string) {
+	if entryID, ok := standupStore.getCronEntry(scheduleID); ok {
+		standupCron.Remove(entryID)
+		standupStore.deleteCronEntry(scheduleID)
+	}
+	standupStore.deleteSchedule(scheduleID)
+}
@@ -0,0 +1,25 @@
+package main
+
+import "time"
+
+// Team is a group of Telex users who share a standup schedule and a
+// channel the aggregated digest gets posted to.
+type Team struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	ChannelID string    `json:"channelId"`
+	Members   []string  `json:"members"` // Telex user IDs
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// StandupSchedule drives when a Team is prompted, what they're asked, and
+// how long the scheduler waits for replies before posting the digest.
+type StandupSchedule struct {
+	ID             string        `json:"id"`
+	TeamID         string        `json:"teamId"`
+	Cron           string        `json:"cron"`
+	Timezone       string        `json:"timezone"`
+	Questions      []string      `json:"questions"`
+	ResponseWindow time.Duration `json:"responseWindow"`
+	CreatedAt      time.Time     `json:"createdAt"`
+}
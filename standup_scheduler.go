@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// standupCron drives every registered StandupSchedule off a single cron
+// instance; per-schedule timezones are applied via the "CRON_TZ=" spec
+// prefix that robfig/cron understands natively.
+var standupCron = cron.New()
+
+// pendingStandup tracks one in-flight standup run: the prompts have gone
+// out and the scheduler is waiting (up to ResponseWindow) for members to
+// reply before it posts the digest.
+type pendingStandup struct {
+	team      *Team
+	schedule  *StandupSchedule
+	mu        sync.Mutex
+	responses map[string]string // member ID -> raw reply text
+}
+
+// pendingByUser maps a member ID to every standup run currently waiting on
+// their reply. It's a slice, not a single run, because a member can belong
+// to more than one team with overlapping standup windows — keying on
+// member ID alone would let the second team's run silently replace the
+// first's, and any reply would only ever count for whichever run claimed
+// the slot last. A reply is recorded against every run the member is
+// currently part of instead.
+var (
+	pendingMu     sync.Mutex
+	pendingByUser = make(map[string][]*pendingStandup)
+)
+
+// startStandupScheduler registers every known schedule with the cron
+// runner and starts it. Called once from main at startup.
+func startStandupScheduler() {
+	for _, schedule := range standupStore.listSchedules() {
+		entryID, err := scheduleStandup(schedule)
+		if err != nil {
+			log.Printf("❌ Failed to register standup schedule %s: %v", schedule.ID, err)
+			continue
+		}
+		standupStore.setCronEntry(schedule.ID, entryID)
+	}
+	standupCron.Start()
+}
+
+// scheduleStandup adds a schedule's cron entry to the running scheduler.
+// It's also called when a schedule is created via the API so it takes
+// effect immediately, without a restart.
+func scheduleStandup(schedule *StandupSchedule) (cron.EntryID, error) {
+	spec := schedule.Cron
+	if schedule.Timezone != "" {
+		spec = fmt.Sprintf("CRON_TZ=%s %s", schedule.Timezone, schedule.Cron)
+	}
+
+	return standupCron.AddFunc(spec, func() {
+		team, ok := standupStore.getTeam(schedule.TeamID)
+		if !ok {
+			log.Printf("⚠️  Schedule %s references unknown team %s", schedule.ID, schedule.TeamID)
+			return
+		}
+		runStandup(team, schedule)
+	})
+}
+
+// runStandup DMs every team member the standup questions and opens a
+// response window. When the window closes, finishStandup posts the digest.
+func runStandup(team *Team, schedule *StandupSchedule) {
+	run := &pendingStandup{
+		team:      team,
+		schedule:  schedule,
+		responses: make(map[string]string),
+	}
+
+	pendingMu.Lock()
+	for _, member := range team.Members {
+		pendingByUser[member] = append(pendingByUser[member], run)
+	}
+	pendingMu.Unlock()
+
+	prompt := fmt.Sprintf("🌅 Standup time for %s! Please reply with:\n%s", team.Name, strings.Join(schedule.Questions, "\n"))
+	for _, member := range team.Members {
+		if err := sendTelexMessage(member, prompt); err != nil {
+			log.Printf("⚠️  Failed to send standup prompt to %s: %v", member, err)
+		}
+	}
+
+	time.AfterFunc(schedule.ResponseWindow, func() {
+		finishStandup(run)
+	})
+}
+
+// recordStandupResponse records message as userID's reply to every standup
+// run currently waiting on them (normally one, but a member on multiple
+// teams with overlapping windows can have more than one open at once). It
+// returns false when userID has no pending standup, so callers can fall
+// back to normal AI handling.
+func recordStandupResponse(userID, message string) bool {
+	pendingMu.Lock()
+	runs := append([]*pendingStandup(nil), pendingByUser[userID]...)
+	pendingMu.Unlock()
+	if len(runs) == 0 {
+		return false
+	}
+
+	for _, run := range runs {
+		run.mu.Lock()
+		run.responses[userID] = message
+		run.mu.Unlock()
+	}
+
+	if err := sendTelexMessage(userID, "✅ Got your standup update, thanks!"); err != nil {
+		log.Printf("⚠️  Failed to ack standup response from %s: %v", userID, err)
+	}
+	return true
+}
+
+// finishStandup closes the response window, nudges anyone who didn't
+// reply in time, and posts the LLM-generated digest to the team channel.
+func finishStandup(run *pendingStandup) {
+	pendingMu.Lock()
+	for _, member := range run.team.Members {
+		remaining := pendingByUser[member][:0]
+		for _, r := range pendingByUser[member] {
+			if r != run {
+				remaining = append(remaining, r)
+			}
+		}
+		if len(remaining) == 0 {
+			delete(pendingByUser, member)
+		} else {
+			pendingByUser[member] = remaining
+		}
+	}
+	pendingMu.Unlock()
+
+	run.mu.Lock()
+	responses := make(map[string]string, len(run.responses))
+	for member, reply := range run.responses {
+		responses[member] = reply
+	}
+	run.mu.Unlock()
+
+	for _, member := range run.team.Members {
+		if _, replied := responses[member]; replied {
+			continue
+		}
+		nudge := fmt.Sprintf("👋 Still waiting on your standup update for %s — reply whenever you can.", run.team.Name)
+		if err := sendTelexMessage(member, nudge); err != nil {
+			log.Printf("⚠️  Failed to send standup reminder to %s: %v", member, err)
+		}
+	}
+
+	digest, err := generateStandupDigest(run.team, responses)
+	if err != nil {
+		log.Printf("❌ Failed to generate standup digest for %s: %v", run.team.Name, err)
+		return
+	}
+
+	if err := sendTelexMessage(run.team.ChannelID, digest); err != nil {
+		log.Printf("❌ Failed to post standup digest for %s: %v", run.team.Name, err)
+	}
+}
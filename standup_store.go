@@ -0,0 +1,205 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// StandupPersistence durably stores teams and schedules so they survive a
+// restart and are visible to every instance behind a load balancer, the
+// same rationale ConversationStore was built for. The SQLite and Postgres
+// backends implement it against the same database they keep turns in, so
+// with either one a CONVO_STORE_BACKEND choice covers both conversations
+// and standup config. The Redis backend doesn't implement it; with Redis,
+// teams and schedules live in memory only. cron entry IDs and
+// in-flight standup runs aren't part of this: entries are cheap to
+// re-register from the persisted schedules on startup (see
+// startStandupScheduler), and resuming a run that was mid-collection when
+// the process restarted is out of scope here.
+type StandupPersistence interface {
+	SaveTeam(team *Team) error
+	DeleteTeam(id string) error
+	LoadTeams() ([]*Team, error)
+
+	SaveSchedule(schedule *StandupSchedule) error
+	DeleteSchedule(id string) error
+	LoadSchedules() ([]*StandupSchedule, error)
+}
+
+// standupPersistence is set from main once conversationStore is
+// initialized, when its backend also implements StandupPersistence. It's
+// nil (and every persistence call below a no-op) if the backend doesn't
+// support it, so the store still works in-memory-only same as before.
+var standupPersistence StandupPersistence
+
+// loadStandupState hydrates standupStore's in-memory maps from
+// standupPersistence, if configured. Called once from main at startup,
+// before the scheduler registers any cron entries.
+func loadStandupState() error {
+	if standupPersistence == nil {
+		return nil
+	}
+
+	teams, err := standupPersistence.LoadTeams()
+	if err != nil {
+		return fmt.Errorf("loading persisted teams: %w", err)
+	}
+	schedules, err := standupPersistence.LoadSchedules()
+	if err != nil {
+		return fmt.Errorf("loading persisted standup schedules: %w", err)
+	}
+
+	standupStore.mu.Lock()
+	defer standupStore.mu.Unlock()
+	for _, team := range teams {
+		standupStore.teams[team.ID] = team
+	}
+	for _, schedule := range schedules {
+		standupStore.schedules[schedule.ID] = schedule
+	}
+	return nil
+}
+
+// standupStoreT is an in-memory registry of teams and their standup
+// schedules, backed by standupPersistence when one is configured.
+// cronEntries tracks which standupCron entry backs each schedule, so it
+// can be removed when the schedule is deleted; entries are never
+// persisted since they're regenerated from the persisted schedules on
+// every startup.
+type standupStoreT struct {
+	mu          sync.RWMutex
+	teams       map[string]*Team
+	schedules   map[string]*StandupSchedule
+	cronEntries map[string]cron.EntryID
+}
+
+var standupStore = &standupStoreT{
+	teams:       make(map[string]*Team),
+	schedules:   make(map[string]*StandupSchedule),
+	cronEntries: make(map[string]cron.EntryID),
+}
+
+// newStandupID generates a unique, roughly-sortable ID for a team or
+// schedule without pulling in a UUID dependency.
+func newStandupID(prefix string) string {
+	return fmt.Sprintf("%s-%d", prefix, time.Now().UnixNano())
+}
+
+func (s *standupStoreT) createTeam(team *Team) {
+	s.mu.Lock()
+	s.teams[team.ID] = team
+	s.mu.Unlock()
+
+	if standupPersistence != nil {
+		if err := standupPersistence.SaveTeam(team); err != nil {
+			log.Printf("⚠️  Failed to persist team %s: %v", team.ID, err)
+		}
+	}
+}
+
+func (s *standupStoreT) getTeam(id string) (*Team, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	team, ok := s.teams[id]
+	return team, ok
+}
+
+func (s *standupStoreT) listTeams() []*Team {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]*Team, 0, len(s.teams))
+	for _, team := range s.teams {
+		out = append(out, team)
+	}
+	return out
+}
+
+func (s *standupStoreT) deleteTeam(id string) {
+	s.mu.Lock()
+	delete(s.teams, id)
+	s.mu.Unlock()
+
+	if standupPersistence != nil {
+		if err := standupPersistence.DeleteTeam(id); err != nil {
+			log.Printf("⚠️  Failed to delete persisted team %s: %v", id, err)
+		}
+	}
+}
+
+func (s *standupStoreT) createSchedule(schedule *StandupSchedule) {
+	s.mu.Lock()
+	s.schedules[schedule.ID] = schedule
+	s.mu.Unlock()
+
+	if standupPersistence != nil {
+		if err := standupPersistence.SaveSchedule(schedule); err != nil {
+			log.Printf("⚠️  Failed to persist standup schedule %s: %v", schedule.ID, err)
+		}
+	}
+}
+
+func (s *standupStoreT) getSchedule(id string) (*StandupSchedule, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	schedule, ok := s.schedules[id]
+	return schedule, ok
+}
+
+func (s *standupStoreT) listSchedules() []*StandupSchedule {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]*StandupSchedule, 0, len(s.schedules))
+	for _, schedule := range s.schedules {
+		out = append(out, schedule)
+	}
+	return out
+}
+
+// listSchedulesForTeam returns every schedule belonging to teamID, so a
+// team delete can cascade to its schedules instead of leaving them orphaned.
+func (s *standupStoreT) listSchedulesForTeam(teamID string) []*StandupSchedule {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var out []*StandupSchedule
+	for _, schedule := range s.schedules {
+		if schedule.TeamID == teamID {
+			out = append(out, schedule)
+		}
+	}
+	return out
+}
+
+func (s *standupStoreT) deleteSchedule(id string) {
+	s.mu.Lock()
+	delete(s.schedules, id)
+	s.mu.Unlock()
+
+	if standupPersistence != nil {
+		if err := standupPersistence.DeleteSchedule(id); err != nil {
+			log.Printf("⚠️  Failed to delete persisted standup schedule %s: %v", id, err)
+		}
+	}
+}
+
+func (s *standupStoreT) setCronEntry(scheduleID string, entryID cron.EntryID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cronEntries[scheduleID] = entryID
+}
+
+func (s *standupStoreT) getCronEntry(scheduleID string) (cron.EntryID, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entryID, ok := s.cronEntries[scheduleID]
+	return entryID, ok
+}
+
+func (s *standupStoreT) deleteCronEntry(scheduleID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.cronEntries, scheduleID)
+}
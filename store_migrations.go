@@ -0,0 +1,129 @@
+package main
+
+import (
+	"database/sql"
+	"log"
+)
+
+// migration is a single forward-only schema change, applied once and
+// tracked in the schema_migrations table.
+type migration struct {
+	id  string
+	ddl map[string]string // dialect ("sqlite"|"postgres") -> DDL
+}
+
+var migrations = []migration{
+	{
+		id: "0001_create_turns",
+		ddl: map[string]string{
+			"sqlite": `CREATE TABLE IF NOT EXISTS turns (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				user_id TEXT NOT NULL,
+				role TEXT NOT NULL,
+				content TEXT NOT NULL,
+				timestamp DATETIME NOT NULL,
+				tokens INTEGER DEFAULT 0,
+				tool_calls TEXT DEFAULT ''
+			)`,
+			"postgres": `CREATE TABLE IF NOT EXISTS turns (
+				id SERIAL PRIMARY KEY,
+				user_id TEXT NOT NULL,
+				role TEXT NOT NULL,
+				content TEXT NOT NULL,
+				timestamp TIMESTAMPTZ NOT NULL,
+				tokens INTEGER DEFAULT 0,
+				tool_calls TEXT DEFAULT ''
+			)`,
+		},
+	},
+	{
+		id: "0002_index_turns_user_timestamp",
+		ddl: map[string]string{
+			"sqlite":   `CREATE INDEX IF NOT EXISTS idx_turns_user_timestamp ON turns (user_id, timestamp DESC)`,
+			"postgres": `CREATE INDEX IF NOT EXISTS idx_turns_user_timestamp ON turns (user_id, timestamp DESC)`,
+		},
+	},
+	{
+		id: "0003_create_teams",
+		ddl: map[string]string{
+			"sqlite": `CREATE TABLE IF NOT EXISTS teams (
+				id TEXT PRIMARY KEY,
+				name TEXT NOT NULL,
+				channel_id TEXT NOT NULL,
+				members TEXT NOT NULL,
+				created_at DATETIME NOT NULL
+			)`,
+			"postgres": `CREATE TABLE IF NOT EXISTS teams (
+				id TEXT PRIMARY KEY,
+				name TEXT NOT NULL,
+				channel_id TEXT NOT NULL,
+				members TEXT NOT NULL,
+				created_at TIMESTAMPTZ NOT NULL
+			)`,
+		},
+	},
+	{
+		id: "0004_create_standup_schedules",
+		ddl: map[string]string{
+			"sqlite": `CREATE TABLE IF NOT EXISTS standup_schedules (
+				id TEXT PRIMARY KEY,
+				team_id TEXT NOT NULL,
+				cron TEXT NOT NULL,
+				timezone TEXT NOT NULL,
+				questions TEXT NOT NULL,
+				response_window_ns INTEGER NOT NULL,
+				created_at DATETIME NOT NULL
+			)`,
+			"postgres": `CREATE TABLE IF NOT EXISTS standup_schedules (
+				id TEXT PRIMARY KEY,
+				team_id TEXT NOT NULL,
+				cron TEXT NOT NULL,
+				timezone TEXT NOT NULL,
+				questions TEXT NOT NULL,
+				response_window_ns BIGINT NOT NULL,
+				created_at TIMESTAMPTZ NOT NULL
+			)`,
+		},
+	},
+}
+
+// runMigrations applies every migration not yet recorded in
+// schema_migrations, in order, on startup.
+func runMigrations(db *sql.DB, dialect string) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (id TEXT PRIMARY KEY)`); err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		var exists int
+		err := db.QueryRow(`SELECT COUNT(*) FROM schema_migrations WHERE id = ?`, m.id).Scan(&exists)
+		if dialect == "postgres" {
+			err = db.QueryRow(`SELECT COUNT(*) FROM schema_migrations WHERE id = $1`, m.id).Scan(&exists)
+		}
+		if err != nil {
+			return err
+		}
+		if exists > 0 {
+			continue
+		}
+
+		ddl, ok := m.ddl[dialect]
+		if !ok {
+			continue
+		}
+		if _, err := db.Exec(ddl); err != nil {
+			return err
+		}
+
+		if dialect == "postgres" {
+			_, err = db.Exec(`INSERT INTO schema_migrations (id) VALUES ($1)`, m.id)
+		} else {
+			_, err = db.Exec(`INSERT INTO schema_migrations (id) VALUES (?)`, m.id)
+		}
+		if err != nil {
+			return err
+		}
+		log.Printf("🗄️  applied migration %s (%s)", m.id, dialect)
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// postgresStore is the recommended backend for horizontally scaled
+// deployments behind a load balancer, since all instances share one database.
+type postgresStore struct {
+	db    *sql.DB
+	ttl   time.Duration
+	locks *userLocks
+}
+
+func newPostgresStore(dsn string, ttl time.Duration) (ConversationStore, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Ping(); err != nil {
+		return nil, err
+	}
+
+	s := &postgresStore{db: db, ttl: ttl, locks: newUserLocks()}
+	if err := runMigrations(db, "postgres"); err != nil {
+		return nil, err
+	}
+	if ttl > 0 {
+		go s.evictLoop()
+	}
+	return s, nil
+}
+
+func (s *postgresStore) AppendTurn(userID string, turn Turn) error {
+	unlock := s.locks.Lock(userID)
+	defer unlock()
+
+	toolCalls, err := json.Marshal(turn.ToolCalls)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(
+		`INSERT INTO turns (user_id, role, content, timestamp, tokens, tool_calls) VALUES ($1, $2, $3, $4, $5, $6)`,
+		userID, turn.Role, turn.Content, turn.Timestamp, turn.Tokens, string(toolCalls),
+	)
+	return err
+}
+
+func (s *postgresStore) GetHistory(userID string, limit, offset int) ([]Turn, error) {
+	rows, err := s.db.Query(
+		`SELECT role, content, timestamp, tokens, tool_calls FROM turns
+		 WHERE user_id = $1 ORDER BY timestamp DESC LIMIT $2 OFFSET $3`,
+		userID, limit, offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var turns []Turn
+	for rows.Next() {
+		var t Turn
+		var toolCalls string
+		if err := rows.Scan(&t.Role, &t.Content, &t.Timestamp, &t.Tokens, &toolCalls); err != nil {
+			return nil, err
+		}
+		if toolCalls != "" {
+			if err := json.Unmarshal([]byte(toolCalls), &t.ToolCalls); err != nil {
+				return nil, err
+			}
+		}
+		turns = append(turns, t)
+	}
+	return turns, rows.Err()
+}
+
+func (s *postgresStore) Summary(userID string) (ConversationSummary, error) {
+	row := s.db.QueryRow(
+		`SELECT COUNT(*), MAX(timestamp) FROM turns WHERE user_id = $1`, userID,
+	)
+
+	var summary ConversationSummary
+	var count sql.NullInt64
+	var lastTimestamp sql.NullTime
+	if err := row.Scan(&count, &lastTimestamp); err != nil {
+		return summary, err
+	}
+	if count.Int64 == 0 {
+		return summary, sql.ErrNoRows
+	}
+
+	summary.UserID = userID
+	summary.MessageCount = int(count.Int64)
+	summary.Timestamp = lastTimestamp.Time
+
+	last, err := s.GetHistory(userID, 1, 0)
+	if err != nil {
+		return summary, err
+	}
+	if len(last) > 0 {
+		summary.LastMessage = last[0].Content
+	}
+	return summary, nil
+}
+
+func (s *postgresStore) AllSummaries() ([]ConversationSummary, error) {
+	rows, err := s.db.Query(`SELECT DISTINCT user_id FROM turns`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var summaries []ConversationSummary
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		summary, err := s.Summary(userID)
+		if err != nil {
+			continue
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, rows.Err()
+}
+
+func (s *postgresStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *postgresStore) SaveTeam(team *Team) error {
+	members, err := json.Marshal(team.Members)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(
+		`INSERT INTO teams (id, name, channel_id, members, created_at) VALUES ($1, $2, $3, $4, $5)
+		 ON CONFLICT (id) DO UPDATE SET name = excluded.name, channel_id = excluded.channel_id, members = excluded.members`,
+		team.ID, team.Name, team.ChannelID, string(members), team.CreatedAt,
+	)
+	return err
+}
+
+func (s *postgresStore) DeleteTeam(id string) error {
+	_, err := s.db.Exec(`DELETE FROM teams WHERE id = $1`, id)
+	return err
+}
+
+func (s *postgresStore) LoadTeams() ([]*Team, error) {
+	rows, err := s.db.Query(`SELECT id, name, channel_id, members, created_at FROM teams`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var teams []*Team
+	for rows.Next() {
+		var t Team
+		var members string
+		if err := rows.Scan(&t.ID, &t.Name, &t.ChannelID, &members, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(members), &t.Members); err != nil {
+			return nil, err
+		}
+		teams = append(teams, &t)
+	}
+	return teams, rows.Err()
+}
+
+func (s *postgresStore) SaveSchedule(schedule *StandupSchedule) error {
+	questions, err := json.Marshal(schedule.Questions)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(
+		`INSERT INTO standup_schedules (id, team_id, cron, timezone, questions, response_window_ns, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)
+		 ON CONFLICT (id) DO UPDATE SET cron = excluded.cron, timezone = excluded.timezone,
+			questions = excluded.questions, response_window_ns = excluded.response_window_ns`,
+		schedule.ID, schedule.TeamID, schedule.Cron, schedule.Timezone, string(questions),
+		schedule.ResponseWindow.Nanoseconds(), schedule.CreatedAt,
+	)
+	return err
+}
+
+func (s *postgresStore) DeleteSchedule(id string) error {
+	_, err := s.db.Exec(`DELETE FROM standup_schedules WHERE id = $1`, id)
+	return err
+}
+
+func (s *postgresStore) LoadSchedules() ([]*StandupSchedule, error) {
+	rows, err := s.db.Query(`SELECT id, team_id, cron, timezone, questions, response_window_ns, created_at FROM standup_schedules`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var schedules []*StandupSchedule
+	for rows.Next() {
+		var sched StandupSchedule
+		var questions string
+		var windowNanos int64
+		if err := rows.Scan(&sched.ID, &sched.TeamID, &sched.Cron, &sched.Timezone, &questions, &windowNanos, &sched.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(questions), &sched.Questions); err != nil {
+			return nil, err
+		}
+		sched.ResponseWindow = time.Duration(windowNanos)
+		schedules = append(schedules, &sched)
+	}
+	return schedules, rows.Err()
+}
+
+func (s *postgresStore) evictLoop() {
+	ticker := time.NewTicker(s.ttl / 10)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		cutoff := time.Now().Add(-s.ttl)
+		if _, err := s.db.Exec(`DELETE FROM turns WHERE timestamp < $1`, cutoff); err != nil {
+			log.Printf("⚠️  conversation store eviction failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisStore keeps transcripts in a per-user sorted set (score = unix nano
+// timestamp) so pagination and TTL eviction are both cheap range queries.
+// It's the backend to reach for when conversations are short-lived and the
+// deployment already runs Redis for other purposes.
+type redisStore struct {
+	client *redis.Client
+	ttl    time.Duration
+	locks  *userLocks
+}
+
+func newRedisStore(addr, password string, ttl time.Duration) (ConversationStore, error) {
+	client := redis.NewClient(&redis.Options{Addr: addr, Password: password})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, err
+	}
+
+	return &redisStore{client: client, ttl: ttl, locks: newUserLocks()}, nil
+}
+
+func turnsKey(userID string) string {
+	return "standup-bot:turns:" + userID
+}
+
+func (s *redisStore) AppendTurn(userID string, turn Turn) error {
+	unlock := s.locks.Lock(userID)
+	defer unlock()
+
+	data, err := json.Marshal(turn)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	key := turnsKey(userID)
+
+	if err := s.client.ZAdd(ctx, key, redis.Z{
+		Score:  float64(turn.Timestamp.UnixNano()),
+		Member: data,
+	}).Err(); err != nil {
+		return err
+	}
+
+	if s.ttl > 0 {
+		// Drop turns older than the TTL, like the SQL backends' evictLoop,
+		// and let an idle user's whole key expire.
+		cutoff := "(" + strconv.FormatInt(time.Now().Add(-s.ttl).UnixNano(), 10)
+		if err := s.client.ZRemRangeByScore(ctx, key, "-inf", cutoff).Err(); err != nil {
+			return err
+		}
+		if err := s.client.Expire(ctx, key, s.ttl).Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *redisStore) GetHistory(userID string, limit, offset int) ([]Turn, error) {
+	ctx := context.Background()
+	raw, err := s.client.ZRevRange(ctx, turnsKey(userID), int64(offset), int64(offset+limit-1)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	turns := make([]Turn, 0, len(raw))
+	for _, item := range raw {
+		var t Turn
+		if err := json.Unmarshal([]byte(item), &t); err != nil {
+			return nil, err
+		}
+		turns = append(turns, t)
+	}
+	return turns, nil
+}
+
+func (s *redisStore) Summary(userID string) (ConversationSummary, error) {
+	ctx := context.Background()
+	count, err := s.client.ZCard(ctx, turnsKey(userID)).Result()
+	if err != nil {
+		return ConversationSummary{}, err
+	}
+	if count == 0 {
+		return ConversationSummary{}, sql.ErrNoRows
+	}
+
+	last, err := s.GetHistory(userID, 1, 0)
+	if err != nil {
+		return ConversationSummary{}, err
+	}
+
+	summary := ConversationSummary{
+		UserID:       userID,
+		MessageCount: int(count),
+	}
+	if len(last) > 0 {
+		summary.LastMessage = last[0].Content
+		summary.Timestamp = last[0].Timestamp
+	}
+	return summary, nil
+}
+
+func (s *redisStore) AllSummaries() ([]ConversationSummary, error) {
+	ctx := context.Background()
+	var userIDs []string
+
+	iter := s.client.Scan(ctx, 0, "standup-bot:turns:*", 0).Iterator()
+	for iter.Next(ctx) {
+		userIDs = append(userIDs, iter.Val()[len("standup-bot:turns:"):])
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	sort.Strings(userIDs)
+
+	summaries := make([]ConversationSummary, 0, len(userIDs))
+	for _, userID := range userIDs {
+		summary, err := s.Summary(userID)
+		if err != nil {
+			continue
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+func (s *redisStore) Close() error {
+	return s.client.Close()
+}
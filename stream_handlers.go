@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handleMessageStreamSSE streams a reply to the userId/message query params as
+// text/event-stream frames, so browser clients can render tokens as they
+// arrive instead of waiting for the full completion.
+func handleMessageStreamSSE(c *gin.Context) {
+	userID := c.Query("userId")
+	message := c.Query("message")
+	if userID == "" || message == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "userId and message query params are required"})
+		return
+	}
+
+	updateConversationContext(userID, message)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), streamResponseTimeout)
+	defer cancel()
+
+	chunks, toolCalls, err := generateAIResponseStream(ctx, userID, message)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+
+	var full strings.Builder
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case chunk, ok := <-chunks:
+			if !ok {
+				return false
+			}
+			if chunk.Err != nil {
+				fmt.Fprintf(w, "event: error\ndata: %s\n\n", chunk.Err.Error())
+				return false
+			}
+			if chunk.Done {
+				fmt.Fprintf(w, "event: done\ndata: [DONE]\n\n")
+				if err := conversationStore.AppendTurn(userID, Turn{
+					Role:      "assistant",
+					Content:   full.String(),
+					Timestamp: time.Now(),
+					ToolCalls: *toolCalls,
+				}); err != nil {
+					log.Printf("⚠️  Failed to persist streamed assistant turn for %s: %v", userID, err)
+				}
+				return false
+			}
+
+			full.WriteString(chunk.Token)
+			writeSSEData(w, chunk.Token)
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	})
+}
+
+// writeSSEData serializes token as one SSE "data:" field. Per the
+// EventSource spec a data field can't contain a raw newline — each line of
+// a multi-line value needs its own "data:" prefix, or everything after the
+// first line is a malformed/unrecognized field and browsers silently drop
+// it. Multi-line tokens are routine here (code blocks, multi-paragraph
+// replies), so split on "\n" rather than assuming a token is one line.
+func writeSSEData(w io.Writer, token string) {
+	for _, line := range strings.Split(token, "\n") {
+		fmt.Fprintf(w, "data: %s\n", line)
+	}
+	fmt.Fprint(w, "\n")
+}
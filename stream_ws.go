@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// wsUpgrader mirrors the SSE endpoint's behavior over a WebSocket, for
+// clients that want a persistent bidirectional connection instead of
+// reconnecting SSE streams per message.
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	// Telex embeds this agent in third-party dashboards, so the origin is
+	// not known ahead of time; auth is still enforced via TELEX_API_KEY
+	// on the inbound webhook, this endpoint just streams replies.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+type wsStreamRequest struct {
+	UserID  string `json:"userId"`
+	Message string `json:"message"`
+}
+
+type wsStreamFrame struct {
+	Type  string `json:"type"` // "token" | "done" | "error"
+	Token string `json:"token,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// handleMessageStreamWS upgrades to a WebSocket and streams one reply per
+// incoming {"userId","message"} frame, keeping the socket open between
+// replies and closing it when the client disconnects or a write fails.
+func handleMessageStreamWS(c *gin.Context) {
+	conn, err := wsUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Printf("⚠️  WebSocket upgrade failed: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	for {
+		var req wsStreamRequest
+		if err := conn.ReadJSON(&req); err != nil {
+			return
+		}
+		if req.UserID == "" || req.Message == "" {
+			conn.WriteJSON(wsStreamFrame{Type: "error", Error: "userId and message are required"})
+			continue
+		}
+
+		updateConversationContext(req.UserID, req.Message)
+
+		ctx, cancel := context.WithTimeout(context.Background(), streamResponseTimeout)
+		chunks, toolCalls, err := generateAIResponseStream(ctx, req.UserID, req.Message)
+		if err != nil {
+			conn.WriteJSON(wsStreamFrame{Type: "error", Error: err.Error()})
+			cancel()
+			continue
+		}
+
+		var full string
+		for chunk := range chunks {
+			if chunk.Err != nil {
+				conn.WriteJSON(wsStreamFrame{Type: "error", Error: chunk.Err.Error()})
+				break
+			}
+			if chunk.Done {
+				break
+			}
+			full += chunk.Token
+			if err := conn.WriteJSON(wsStreamFrame{Type: "token", Token: chunk.Token}); err != nil {
+				cancel()
+				return
+			}
+		}
+		cancel()
+
+		if err := conn.WriteJSON(wsStreamFrame{Type: "done"}); err != nil {
+			return
+		}
+		if err := conversationStore.AppendTurn(req.UserID, Turn{
+			Role:      "assistant",
+			Content:   full,
+			Timestamp: time.Now(),
+			ToolCalls: *toolCalls,
+		}); err != nil {
+			log.Printf("⚠️  Failed to persist streamed assistant turn for %s: %v", req.UserID, err)
+		}
+	}
+}
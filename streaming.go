@@ -0,0 +1,250 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// streamResponseTimeout bounds how long a single streamed reply may take
+// end to end, so a stalled provider connection can't hang a webhook forever.
+const streamResponseTimeout = 60 * time.Second
+
+// editEveryNTokens controls how often the in-flight Telex message is edited
+// with the growing reply, trading message-edit API calls for perceived latency.
+const editEveryNTokens = 20
+
+// StreamChunk is a single increment of a streamed reply: either a token, the
+// terminal Done signal, or a mid-stream error.
+type StreamChunk struct {
+	Token string
+	Done  bool
+	Err   error
+}
+
+// generateAIResponseStream mirrors generateAIResponse but streams tokens back
+// on a channel as they arrive from the active provider chain, and is
+// cancelled via ctx if the caller (an HTTP client or webhook handler) goes
+// away mid-reply. Tool calls are driven through runToolLoopStream so this
+// path has the same tool-calling behavior as the non-streaming one; the
+// returned toolCalls slice is populated once the channel closes.
+func generateAIResponseStream(ctx context.Context, userID, message string) (<-chan StreamChunk, *[]ToolCallRecord, error) {
+	history, err := conversationStore.GetHistory(userID, maxContextTurns, 0)
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading conversation history: %w", err)
+	}
+
+	messages := buildContextMessages(history, message)
+	toolCalls := &[]ToolCallRecord{}
+	chunks, err := runToolLoopStream(ctx, messages, LLMParams{Temperature: 0.7, MaxTokens: 1024}, llmChain.Stream, toolCalls)
+	return chunks, toolCalls, err
+}
+
+// streamOpenAICompatible opens a streaming chat completion against any
+// OpenAI-compatible endpoint (Groq, OpenAI, LocalAI) and incrementally
+// parses the SSE `data:` frames, emitting each token on the returned channel
+// as it decodes, until the `[DONE]` sentinel, a mid-stream error, or ctx
+// cancellation closes it.
+func streamOpenAICompatible(ctx context.Context, clientName, url, authHeader string, reqBody map[string]interface{}) (<-chan StreamChunk, error) {
+	reqBody["stream"] = true
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", authHeader)
+	req.Header.Set("Accept", "text/event-stream")
+
+	client := newHTTPClient(clientName)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("LLM API error (%d): %s", resp.StatusCode, string(body))
+	}
+
+	out := make(chan StreamChunk)
+
+	go func() {
+		defer close(out)
+		defer resp.Body.Close()
+
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+		for scanner.Scan() {
+			line := scanner.Text()
+			if !strings.HasPrefix(line, "data: ") {
+				continue
+			}
+
+			data := strings.TrimPrefix(line, "data: ")
+			if data == "[DONE]" {
+				out <- StreamChunk{Done: true}
+				return
+			}
+
+			var frame struct {
+				Choices []struct {
+					Delta struct {
+						Content string `json:"content"`
+					} `json:"delta"`
+				} `json:"choices"`
+			}
+			if err := json.Unmarshal([]byte(data), &frame); err != nil {
+				continue
+			}
+			if len(frame.Choices) == 0 || frame.Choices[0].Delta.Content == "" {
+				continue
+			}
+
+			select {
+			case out <- StreamChunk{Token: frame.Choices[0].Delta.Content}:
+			case <-ctx.Done():
+				out <- StreamChunk{Err: ctx.Err()}
+				return
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			out <- StreamChunk{Err: err}
+		}
+	}()
+
+	return out, nil
+}
+
+// sendTelexTypingEvent tells Telex the agent is composing a reply, so
+// streaming clients see a typing indicator while tokens are still arriving.
+func sendTelexTypingEvent(toUserID string) error {
+	payload := map[string]string{
+		"from": agentID,
+		"to":   toUserID,
+		"type": "typing",
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", telexBaseURL+"/events", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+telexAPIKey)
+
+	client := newHTTPClient("telex-sse")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("telex typing event error: %s", string(body))
+	}
+	return nil
+}
+
+// sendTelexMessageInitial posts the first (possibly empty) chunk of a
+// streamed reply and returns the message ID Telex assigned, so later tokens
+// can be applied as edits to the same message.
+func sendTelexMessageInitial(toUserID, content string) (string, error) {
+	msg := TelexMessage{
+		From:      agentID,
+		To:        toUserID,
+		Content:   content,
+		Timestamp: time.Now(),
+		Type:      "text",
+	}
+
+	jsonData, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest("POST", telexBaseURL+"/messages", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+telexAPIKey)
+
+	client := newHTTPClient("telex")
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("telex API error: %s", string(body))
+	}
+
+	var created struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(body, &created); err != nil {
+		return "", err
+	}
+	return created.ID, nil
+}
+
+// editTelexMessage replaces the content of a message previously created by
+// sendTelexMessageInitial, used to progressively reveal a streamed reply.
+func editTelexMessage(messageID, toUserID, content string) error {
+	payload := map[string]string{
+		"to":      toUserID,
+		"content": content,
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("PATCH", telexBaseURL+"/messages/"+messageID, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+telexAPIKey)
+
+	client := newHTTPClient("telex")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("telex API error: %s", string(body))
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// calculatorTool evaluates a basic arithmetic expression (+ - * / and
+// parentheses), so the model doesn't have to do mental math in free text.
+type calculatorTool struct{}
+
+func newCalculatorTool() *calculatorTool { return &calculatorTool{} }
+
+func (t *calculatorTool) Name() string { return "calculator" }
+
+func (t *calculatorTool) Description() string {
+	return "Evaluates an arithmetic expression with +, -, *, /, and parentheses."
+}
+
+func (t *calculatorTool) JSONSchema() string {
+	return `{"type":"object","properties":{"expression":{"type":"string"}},"required":["expression"]}`
+}
+
+func (t *calculatorTool) Invoke(args map[string]interface{}) (string, error) {
+	expr, _ := args["expression"].(string)
+	if expr == "" {
+		return "", fmt.Errorf("calculator requires an expression parameter")
+	}
+
+	result, err := evalExpression(expr)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatFloat(result, 'g', -1, 64), nil
+}
+
+// exprParser is a minimal recursive-descent parser for +, -, *, /, and
+// parenthesized sub-expressions over float64 operands.
+type exprParser struct {
+	input string
+	pos   int
+}
+
+func evalExpression(expr string) (float64, error) {
+	p := &exprParser{input: strings.ReplaceAll(expr, " ", "")}
+	result, err := p.parseExpr()
+	if err != nil {
+		return 0, err
+	}
+	if p.pos != len(p.input) {
+		return 0, fmt.Errorf("unexpected character %q at position %d", p.input[p.pos], p.pos)
+	}
+	return result, nil
+}
+
+func (p *exprParser) parseExpr() (float64, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return 0, err
+	}
+
+	for p.pos < len(p.input) {
+		op := p.input[p.pos]
+		if op != '+' && op != '-' {
+			break
+		}
+		p.pos++
+
+		right, err := p.parseTerm()
+		if err != nil {
+			return 0, err
+		}
+		if op == '+' {
+			left += right
+		} else {
+			left -= right
+		}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseTerm() (float64, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return 0, err
+	}
+
+	for p.pos < len(p.input) {
+		op := p.input[p.pos]
+		if op != '*' && op != '/' {
+			break
+		}
+		p.pos++
+
+		right, err := p.parseFactor()
+		if err != nil {
+			return 0, err
+		}
+		if op == '*' {
+			left *= right
+		} else {
+			if right == 0 {
+				return 0, fmt.Errorf("division by zero")
+			}
+			left /= right
+		}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseFactor() (float64, error) {
+	if p.pos >= len(p.input) {
+		return 0, fmt.Errorf("unexpected end of expression")
+	}
+
+	if p.input[p.pos] == '-' {
+		p.pos++
+		val, err := p.parseFactor()
+		return -val, err
+	}
+
+	if p.input[p.pos] == '(' {
+		p.pos++
+		val, err := p.parseExpr()
+		if err != nil {
+			return 0, err
+		}
+		if p.pos >= len(p.input) || p.input[p.pos] != ')' {
+			return 0, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return val, nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.input) && (unicode.IsDigit(rune(p.input[p.pos])) || p.input[p.pos] == '.') {
+		p.pos++
+	}
+	if start == p.pos {
+		return 0, fmt.Errorf("expected a number at position %d", p.pos)
+	}
+	return strconv.ParseFloat(p.input[start:p.pos], 64)
+}
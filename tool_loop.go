@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// maxToolIterations caps how many times the model can call tools in one
+// turn, so a misbehaving loop can't run forever against a live provider.
+const maxToolIterations = 5
+
+type llmCompleteFunc func(ctx context.Context, messages []LLMMessage, params LLMParams) (LLMResponse, error)
+
+// runToolLoop drives the XML function-calling convention on top of any
+// LLMProvider's Complete method: it appends the function-calls stop
+// sequence, executes whatever <invoke> blocks the model emits, feeds the
+// <function_results> back in as the next user turn, and repeats until the
+// model stops calling tools or maxToolIterations is hit.
+func runToolLoop(ctx context.Context, messages []LLMMessage, params LLMParams, complete llmCompleteFunc) (LLMResponse, []ToolCallRecord, error) {
+	params.Stop = append(append([]string{}, params.Stop...), functionCallsStopSequence)
+
+	var allCalls []ToolCallRecord
+
+	for i := 0; i < maxToolIterations; i++ {
+		resp, err := complete(ctx, messages, params)
+		if err != nil {
+			return LLMResponse{}, allCalls, err
+		}
+
+		block, ok := parseFunctionCalls(resp.Content)
+		if !ok {
+			return resp, allCalls, nil
+		}
+
+		resultsText, calls := executeFunctionCalls(block)
+		allCalls = append(allCalls, calls...)
+
+		messages = append(messages,
+			LLMMessage{Role: "assistant", Content: resp.Content},
+			LLMMessage{Role: "user", Content: resultsText},
+		)
+	}
+
+	return LLMResponse{}, allCalls, fmt.Errorf("exceeded max tool-call iterations (%d)", maxToolIterations)
+}
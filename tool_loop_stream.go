@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type llmStreamFunc func(ctx context.Context, messages []LLMMessage, params LLMParams) (<-chan StreamChunk, error)
+
+// runToolLoopStream is the streaming counterpart to runToolLoop. It forwards
+// tokens to the caller as they arrive, but holds back anything that could be
+// the start of a <function_calls> block until it's confirmed either safe (not
+// actually a tool call, so the held-back text is flushed) or a complete
+// block — which it then executes, feeding the results back in as the next
+// turn and opening a fresh stream, same as runToolLoop does for non-streaming
+// calls. toolCalls accumulates a record of every tool invocation made across
+// the loop; it's safe for the caller to read once the returned channel closes.
+func runToolLoopStream(ctx context.Context, messages []LLMMessage, params LLMParams, stream llmStreamFunc, toolCalls *[]ToolCallRecord) (<-chan StreamChunk, error) {
+	params.Stop = append(append([]string{}, params.Stop...), functionCallsStopSequence)
+
+	out := make(chan StreamChunk)
+
+	go func() {
+		defer close(out)
+
+		for i := 0; i < maxToolIterations; i++ {
+			chunks, err := stream(ctx, messages, params)
+			if err != nil {
+				out <- StreamChunk{Err: err}
+				return
+			}
+
+			var full strings.Builder
+			flushed := 0
+			tagDetected := false
+			var streamErr error
+
+			for chunk := range chunks {
+				if chunk.Err != nil {
+					streamErr = chunk.Err
+					break
+				}
+				if chunk.Done {
+					break
+				}
+
+				full.WriteString(chunk.Token)
+				text := full.String()
+
+				if tagDetected || strings.Contains(text, "<function_calls>") {
+					tagDetected = true
+					continue
+				}
+
+				safeLen := len(text) - overlapWithPrefix(text, "<function_calls>")
+				if safeLen > flushed {
+					select {
+					case out <- StreamChunk{Token: text[flushed:safeLen]}:
+					case <-ctx.Done():
+						out <- StreamChunk{Err: ctx.Err()}
+						return
+					}
+					flushed = safeLen
+				}
+			}
+
+			if streamErr != nil {
+				out <- StreamChunk{Err: streamErr}
+				return
+			}
+
+			text := full.String()
+
+			block, ok := parseFunctionCalls(text)
+			if !ok {
+				if flushed < len(text) {
+					select {
+					case out <- StreamChunk{Token: text[flushed:]}:
+					case <-ctx.Done():
+						out <- StreamChunk{Err: ctx.Err()}
+						return
+					}
+				}
+				out <- StreamChunk{Done: true}
+				return
+			}
+
+			resultsText, calls := executeFunctionCalls(block)
+			*toolCalls = append(*toolCalls, calls...)
+
+			messages = append(messages,
+				LLMMessage{Role: "assistant", Content: text},
+				LLMMessage{Role: "user", Content: resultsText},
+			)
+		}
+
+		out <- StreamChunk{Err: fmt.Errorf("exceeded max tool-call iterations (%d)", maxToolIterations)}
+	}()
+
+	return out, nil
+}
+
+// overlapWithPrefix returns the length of the longest suffix of s that is
+// also a partial (non-full) prefix of pattern, so a token streamer can
+// safely flush s except for a possible match straddling the next chunk.
+func overlapWithPrefix(s, pattern string) int {
+	maxLen := len(pattern) - 1
+	if maxLen > len(s) {
+		maxLen = len(s)
+	}
+	for l := maxLen; l > 0; l-- {
+		if strings.HasSuffix(s, pattern[:l]) {
+			return l
+		}
+	}
+	return 0
+}
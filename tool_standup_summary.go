@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// standupSummarizerTool collapses raw, newline-separated standup responses
+// ("name: update text" per line) into a numbered digest. It's a lightweight
+// formatting aid for the model to call mid-conversation; the full
+// yesterday/today/blockers digest posted to a team channel is generated
+// directly by the standup scheduler, not through this tool.
+type standupSummarizerTool struct{}
+
+func newStandupSummarizerTool() *standupSummarizerTool { return &standupSummarizerTool{} }
+
+func (t *standupSummarizerTool) Name() string { return "standup_summarizer" }
+
+func (t *standupSummarizerTool) Description() string {
+	return "Condenses newline-separated standup responses into a numbered digest."
+}
+
+func (t *standupSummarizerTool) JSONSchema() string {
+	return `{"type":"object","properties":{"responses":{"type":"string"}},"required":["responses"]}`
+}
+
+func (t *standupSummarizerTool) Invoke(args map[string]interface{}) (string, error) {
+	responses, _ := args["responses"].(string)
+	if strings.TrimSpace(responses) == "" {
+		return "", fmt.Errorf("standup_summarizer requires a responses parameter")
+	}
+
+	lines := strings.Split(strings.TrimSpace(responses), "\n")
+
+	var digest strings.Builder
+	digest.WriteString(fmt.Sprintf("%d standup response(s):\n", len(lines)))
+	for i, line := range lines {
+		digest.WriteString(fmt.Sprintf("%d. %s\n", i+1, strings.TrimSpace(line)))
+	}
+	return digest.String(), nil
+}
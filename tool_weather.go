@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// weatherTool reports the current conditions for a location via wttr.in's
+// plain-text endpoint, which needs no API key.
+type weatherTool struct{}
+
+func newWeatherTool() *weatherTool { return &weatherTool{} }
+
+func (t *weatherTool) Name() string { return "weather" }
+
+func (t *weatherTool) Description() string {
+	return "Returns current weather conditions for a city or location name."
+}
+
+func (t *weatherTool) JSONSchema() string {
+	return `{"type":"object","properties":{"location":{"type":"string"}},"required":["location"]}`
+}
+
+func (t *weatherTool) Invoke(args map[string]interface{}) (string, error) {
+	location, _ := args["location"].(string)
+	if location == "" {
+		return "", fmt.Errorf("weather requires a location parameter")
+	}
+
+	client := newHTTPClient("weather")
+	resp, err := client.Get("https://wttr.in/" + url.PathEscape(location) + "?format=3")
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("weather lookup failed (%d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return strings.TrimSpace(string(body)), nil
+}
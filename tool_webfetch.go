@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// maxWebFetchChars bounds how much of a fetched page is handed back to the
+// model, so one tool call can't blow out the context window.
+const maxWebFetchChars = 2000
+
+// webFetchTool retrieves a URL and returns the start of its body, so the
+// model can ground answers in a live page instead of guessing.
+type webFetchTool struct{}
+
+func newWebFetchTool() *webFetchTool { return &webFetchTool{} }
+
+func (t *webFetchTool) Name() string { return "web_fetch" }
+
+func (t *webFetchTool) Description() string {
+	return "Fetches a URL over HTTP(S) and returns the start of its body as text."
+}
+
+func (t *webFetchTool) JSONSchema() string {
+	return `{"type":"object","properties":{"url":{"type":"string"}},"required":["url"]}`
+}
+
+func (t *webFetchTool) Invoke(args map[string]interface{}) (string, error) {
+	url, _ := args["url"].(string)
+	if url == "" {
+		return "", fmt.Errorf("web_fetch requires a url parameter")
+	}
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return "", fmt.Errorf("web_fetch only supports http(s) URLs")
+	}
+
+	client := newHTTPClient("web_fetch")
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxWebFetchChars*4))
+	if err != nil {
+		return "", err
+	}
+
+	text := strings.TrimSpace(string(body))
+	if len(text) > maxWebFetchChars {
+		text = text[:maxWebFetchChars]
+	}
+	return text, nil
+}
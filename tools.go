@@ -0,0 +1,42 @@
+package main
+
+import "fmt"
+
+// Tool is a callable function the model can invoke using the XML
+// function-calling convention (<function_calls><invoke name="...">...).
+type Tool interface {
+	Name() string
+	Description() string
+	JSONSchema() string
+	Invoke(args map[string]interface{}) (string, error)
+}
+
+// toolRegistry holds every built-in tool, keyed by name, looked up when the
+// model emits an <invoke>.
+var toolRegistry = map[string]Tool{}
+
+func registerTool(t Tool) {
+	toolRegistry[t.Name()] = t
+}
+
+func init() {
+	registerTool(newWebFetchTool())
+	registerTool(newCalculatorTool())
+	registerTool(newWeatherTool())
+	registerTool(newStandupSummarizerTool())
+}
+
+// toolsSystemPrompt describes every registered tool and the exact XML
+// convention the model must use to call them, so it can be appended to the
+// base system prompt whenever tools are available.
+func toolsSystemPrompt() string {
+	prompt := "You have access to the following tools. To use one, respond with exactly:\n" +
+		"<function_calls>\n<invoke name=\"tool_name\">\n<parameter name=\"param\">value</parameter>\n</invoke>\n</function_calls>\n\n" +
+		"You may invoke multiple tools in one block. Stop generating immediately after the closing </function_calls> tag " +
+		"and wait for <function_results> before continuing.\n\nAvailable tools:\n"
+
+	for _, t := range toolRegistry {
+		prompt += fmt.Sprintf("- %s: %s\n  schema: %s\n", t.Name(), t.Description(), t.JSONSchema())
+	}
+	return prompt
+}
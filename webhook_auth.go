@@ -0,0 +1,102 @@
+package main
+
+import (
+	"container/list"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// webhookSecret signs Telex webhook payloads, independent of telexAPIKey
+// (which authenticates the Bearer header, not the body). Set via
+// WEBHOOK_SECRET; HMAC verification is skipped with a warning if unset, so
+// existing deployments keep working until they opt in.
+var webhookSecret string
+
+// webhookTimestampSkew bounds how old (or far in the future) an
+// X-Telex-Timestamp may be before a signed request is rejected as stale.
+const webhookTimestampSkew = 5 * time.Minute
+
+// verifyWebhookSignature checks that signature is the hex-encoded
+// HMAC-SHA256 of "timestamp.body" under webhookSecret, that timestamp is
+// within webhookTimestampSkew of now, and that this exact signature hasn't
+// been seen before, so a captured request can't be replayed.
+func verifyWebhookSignature(body []byte, signature, timestamp string) error {
+	if signature == "" {
+		return fmt.Errorf("missing X-Telex-Signature header")
+	}
+	if timestamp == "" {
+		return fmt.Errorf("missing X-Telex-Timestamp header")
+	}
+
+	sentUnix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid X-Telex-Timestamp: %w", err)
+	}
+	if skew := time.Since(time.Unix(sentUnix, 0)); skew > webhookTimestampSkew || skew < -webhookTimestampSkew {
+		return fmt.Errorf("X-Telex-Timestamp outside allowed skew window")
+	}
+
+	mac := hmac.New(sha256.New, []byte(webhookSecret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return fmt.Errorf("signature mismatch")
+	}
+
+	if !webhookReplayCache.checkAndStore(signature) {
+		return fmt.Errorf("replayed webhook signature")
+	}
+
+	return nil
+}
+
+// webhookReplayCache is the process-wide bounded LRU of recently seen
+// signatures, so the same valid request can't be resubmitted within the
+// skew window.
+var webhookReplayCache = newLRUReplayCache(1000)
+
+type lruReplayCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	index    map[string]*list.Element
+}
+
+func newLRUReplayCache(capacity int) *lruReplayCache {
+	return &lruReplayCache{
+		capacity: capacity,
+		order:    list.New(),
+		index:    make(map[string]*list.Element),
+	}
+}
+
+// checkAndStore returns false if key has already been recorded, otherwise
+// records it and returns true, evicting the oldest entry once over capacity.
+func (c *lruReplayCache) checkAndStore(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, seen := c.index[key]; seen {
+		return false
+	}
+
+	c.index[key] = c.order.PushFront(key)
+
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.index, oldest.Value.(string))
+		}
+	}
+
+	return true
+}